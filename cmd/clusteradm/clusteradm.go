@@ -3,6 +3,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"os"
 
@@ -26,23 +27,32 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	"open-cluster-management.io/clusteradm/pkg/helpers"
 
 	// commands
 	acceptclusters "open-cluster-management.io/clusteradm/pkg/cmd/accept"
 	addon "open-cluster-management.io/clusteradm/pkg/cmd/addon"
+	"open-cluster-management.io/clusteradm/pkg/cmd/claim"
 	clean "open-cluster-management.io/clusteradm/pkg/cmd/clean"
 	"open-cluster-management.io/clusteradm/pkg/cmd/clusterset"
+	"open-cluster-management.io/clusteradm/pkg/cmd/cordon"
 	"open-cluster-management.io/clusteradm/pkg/cmd/create"
 	deletecmd "open-cluster-management.io/clusteradm/pkg/cmd/delete"
+	"open-cluster-management.io/clusteradm/pkg/cmd/drain"
 	"open-cluster-management.io/clusteradm/pkg/cmd/get"
 	inithub "open-cluster-management.io/clusteradm/pkg/cmd/init"
 	"open-cluster-management.io/clusteradm/pkg/cmd/install"
 	joinhub "open-cluster-management.io/clusteradm/pkg/cmd/join"
+	"open-cluster-management.io/clusteradm/pkg/cmd/label"
+	"open-cluster-management.io/clusteradm/pkg/cmd/logs"
 	"open-cluster-management.io/clusteradm/pkg/cmd/proxy"
+	"open-cluster-management.io/clusteradm/pkg/cmd/rollout"
+	"open-cluster-management.io/clusteradm/pkg/cmd/taint"
 	"open-cluster-management.io/clusteradm/pkg/cmd/uninstall"
 	"open-cluster-management.io/clusteradm/pkg/cmd/unjoin"
 	"open-cluster-management.io/clusteradm/pkg/cmd/upgrade"
 	"open-cluster-management.io/clusteradm/pkg/cmd/version"
+	"open-cluster-management.io/clusteradm/pkg/cmd/work"
 )
 
 func main() {
@@ -117,8 +127,18 @@ func main() {
 			Message: "Cluster Management commands:",
 			Commands: []*cobra.Command{
 				addon.NewCmd(clusteradmFlags, streams),
+				label.NewAnnotateCmd(clusteradmFlags, streams),
+				claim.NewCmd(clusteradmFlags, streams),
 				clusterset.NewCmd(clusteradmFlags, streams),
+				cordon.NewCmd(clusteradmFlags, streams),
+				cordon.NewUncordonCmd(clusteradmFlags, streams),
+				drain.NewCmd(clusteradmFlags, streams),
+				label.NewCmd(clusteradmFlags, streams),
+				logs.NewCmd(clusteradmFlags, streams),
 				proxy.NewCmd(clusteradmFlags, streams),
+				rollout.NewCmd(clusteradmFlags, streams),
+				taint.NewCmd(clusteradmFlags, streams),
+				work.NewCmd(clusteradmFlags, streams),
 			},
 		},
 	}
@@ -134,6 +154,11 @@ func main() {
 	}
 	klog.Flush()
 	if err != nil {
+		// the exit code of the commands run in-process, e.g. kubectl diff or exec, is passed through
+		var exitErr *helpers.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
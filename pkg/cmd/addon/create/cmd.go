@@ -14,6 +14,10 @@ import (
 var example = `
 Create an addon from manifests by using AddonTemplate
 %[1]s addon create helloworld -f deployment.yaml
+
+Create an addon from a helm chart in a repository, keeping the variables like {{CLUSTER_NAME}} in the values files,
+and exposing the image tag as the variable IMAGE_TAG of an AddOnDeploymentConfig
+%[1]s addon create helloworld --helm-chart ocm/helloworld --values values.yaml --expose-value IMAGE_TAG=image.tag
 `
 
 // NewCmd creates a cammand to create an addon
@@ -53,6 +57,19 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 		"the cluster namespace for the addon agent")
 	cmd.Flags().StringSliceVar(&o.Labels, "labels", []string{}, "Labels to add to the ClusterManagementAddOn and AddOnTemplate resources (eg. key1=value1,key2=value2)")
 	cmd.Flags().StringVar(&o.PlacementRef, "placement-ref", "", "The namespace/name reference to a Placement resource for automatic addon installation (eg. namespace/placement-name)")
+	cmd.Flags().StringVar(&o.HelmChart, "helm-chart", "",
+		"The local directory or archive of a helm chart, or a chart in a helm repository in the format of {repo}/{chart}, "+
+			"rendered into the manifests of the addon")
+	cmd.Flags().StringVar(&o.HelmVersion, "helm-version", "", "The version of the helm chart in a repository, the latest if not specified")
+	cmd.Flags().StringArrayVar(&o.HelmValues, "values", []string{},
+		"The values files of the helm chart, the variables like {{CLUSTER_NAME}} are kept in the manifests")
+	cmd.Flags().StringVar(&o.HelmNamespace, "helm-namespace", "open-cluster-management-agent-addon",
+		"The release namespace to render the helm chart")
+	cmd.Flags().StringArrayVar(&o.ExposedValues, "expose-value", []string{},
+		"A string value of the helm chart exposed as a customized variable of an AddOnDeploymentConfig, "+
+			"in the format of [{name}=]{path}, e.g. IMAGE_TAG=image.tag")
+	cmd.Flags().StringVar(&o.ConfigNamespace, "config-namespace", "open-cluster-management-hub",
+		"The namespace of the AddOnDeploymentConfig of the exposed values")
 	o.FileNameFlags.AddFlags(cmd.Flags())
 
 	return cmd
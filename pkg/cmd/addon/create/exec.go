@@ -4,11 +4,14 @@ package create
 import (
 	"context"
 	"fmt"
+	"os"
+	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/resource"
 
@@ -16,9 +19,16 @@ import (
 	addonclientset "open-cluster-management.io/api/client/addon/clientset/versioned"
 	workapiv1 "open-cluster-management.io/api/work/v1"
 
+	"open-cluster-management.io/clusteradm/pkg/helpers/helm"
 	"open-cluster-management.io/clusteradm/pkg/helpers/parse"
 )
 
+var (
+	// variableNamePattern is the validation of the names of the customized variables
+	variableNamePattern   = regexp.MustCompile(`^[a-zA-Z_][_a-zA-Z0-9]*$`)
+	nonVariableCharacters = regexp.MustCompile(`[^_a-zA-Z0-9]`)
+)
+
 func newAddonTemplate(o *Options) (*addonv1alpha1.AddOnTemplate, error) {
 	manifests, err := o.readManifests()
 	if err != nil {
@@ -101,6 +111,30 @@ func newClusterManagementAddon(o *Options) (*addonv1alpha1.ClusterManagementAddO
 		}
 	}
 
+	supportedConfigs := []addonv1alpha1.ConfigMeta{
+		{
+			ConfigGroupResource: addonv1alpha1.ConfigGroupResource{
+				Group:    addonv1alpha1.GroupVersion.Group,
+				Resource: "addontemplates",
+			},
+			DefaultConfig: &addonv1alpha1.ConfigReferent{
+				Name: o.templateName(),
+			},
+		},
+	}
+	if len(o.ExposedValues) > 0 {
+		supportedConfigs = append(supportedConfigs, addonv1alpha1.ConfigMeta{
+			ConfigGroupResource: addonv1alpha1.ConfigGroupResource{
+				Group:    addonv1alpha1.GroupVersion.Group,
+				Resource: "addondeploymentconfigs",
+			},
+			DefaultConfig: &addonv1alpha1.ConfigReferent{
+				Namespace: o.ConfigNamespace,
+				Name:      o.Name,
+			},
+		})
+	}
+
 	cma := &addonv1alpha1.ClusterManagementAddOn{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   o.Name,
@@ -110,18 +144,8 @@ func newClusterManagementAddon(o *Options) (*addonv1alpha1.ClusterManagementAddO
 			},
 		},
 		Spec: addonv1alpha1.ClusterManagementAddOnSpec{
-			SupportedConfigs: []addonv1alpha1.ConfigMeta{
-				{
-					ConfigGroupResource: addonv1alpha1.ConfigGroupResource{
-						Group:    addonv1alpha1.GroupVersion.Group,
-						Resource: "addontemplates",
-					},
-					DefaultConfig: &addonv1alpha1.ConfigReferent{
-						Name: o.templateName(),
-					},
-				},
-			},
-			InstallStrategy: installStrategy,
+			SupportedConfigs: supportedConfigs,
+			InstallStrategy:  installStrategy,
 		},
 	}
 
@@ -152,8 +176,19 @@ func (o *Options) Validate() (err error) {
 		return fmt.Errorf("addon version must be specified")
 	}
 
-	if len(*o.FileNameFlags.Filenames) == 0 {
-		return fmt.Errorf("manifest files must be specified")
+	if len(*o.FileNameFlags.Filenames) == 0 && len(o.HelmChart) == 0 {
+		return fmt.Errorf("manifest files or a helm chart must be specified")
+	}
+	if len(*o.FileNameFlags.Filenames) > 0 && len(o.HelmChart) > 0 {
+		return fmt.Errorf("manifest files and a helm chart can only specify one")
+	}
+	if len(o.HelmChart) == 0 && (len(o.HelmValues) > 0 || len(o.ExposedValues) > 0 || len(o.HelmVersion) > 0) {
+		return fmt.Errorf("--values, --expose-value and --helm-version can only be specified with --helm-chart")
+	}
+	for _, value := range o.ExposedValues {
+		if _, _, err := parseExposedValue(value); err != nil {
+			return err
+		}
 	}
 
 	// Validate placement-ref format if provided
@@ -178,6 +213,19 @@ func (o *Options) Run() error {
 		return err
 	}
 
+	if len(o.HelmChart) > 0 {
+		if err := o.loadChart(); err != nil {
+			return err
+		}
+	}
+
+	// the deployment config is applied before the cma referring to it
+	if len(o.ExposedValues) > 0 {
+		if err := o.applyDeploymentConfig(addonClient); err != nil {
+			return err
+		}
+	}
+
 	if err := o.applyCMA(addonClient); err != nil {
 		return err
 	}
@@ -250,7 +298,158 @@ func (o *Options) applyTemplate(addonClient addonclientset.Interface) error {
 	return nil
 }
 
+func newDeploymentConfig(o *Options) (*addonv1alpha1.AddOnDeploymentConfig, error) {
+	labels, err := o.parseLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	return &addonv1alpha1.AddOnDeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.Name,
+			Namespace: o.ConfigNamespace,
+			Labels:    labels,
+		},
+		Spec: addonv1alpha1.AddOnDeploymentConfigSpec{
+			CustomizedVariables: o.customizedVariables,
+		},
+	}, nil
+}
+
+func (o *Options) applyDeploymentConfig(addonClient addonclientset.Interface) error {
+	config, err := newDeploymentConfig(o)
+	if err != nil {
+		return err
+	}
+
+	originalConfig, err := addonClient.AddonV1alpha1().AddOnDeploymentConfigs(o.ConfigNamespace).Get(context.TODO(), o.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err := addonClient.AddonV1alpha1().AddOnDeploymentConfigs(o.ConfigNamespace).Create(context.TODO(), config, metav1.CreateOptions{})
+		fmt.Fprintf(o.Streams.Out, "AddOnDeploymentConfig %s/%s is created\n", o.ConfigNamespace, o.Name)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if !o.Overwrite {
+		fmt.Fprintf(o.Streams.Out, "AddOnDeploymentConfig %s/%s is not updated when overwrite is disabled\n", o.ConfigNamespace, o.Name)
+		return nil
+	}
+
+	// the other fields of the config may be set by the users, only the variables are updated
+	originalConfig.Spec.CustomizedVariables = config.Spec.CustomizedVariables
+	if _, err = addonClient.AddonV1alpha1().AddOnDeploymentConfigs(o.ConfigNamespace).Update(context.TODO(), originalConfig, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Streams.Out, "AddOnDeploymentConfig %s/%s is updated\n", o.ConfigNamespace, o.Name)
+	return nil
+}
+
+// loadChart locates the helm chart and merges its values with the values files. The variables like
+// {{CLUSTER_NAME}} in the values files, and the exposed values, are replaced by placeholders in the values,
+// which are restored to the variables in the rendered manifests.
+func (o *Options) loadChart() error {
+	chartPath, err := helm.LocateChart(o.HelmChart, o.HelmVersion)
+	if err != nil {
+		return err
+	}
+
+	o.variables = helm.Variables{}
+	var valuesFiles [][]byte
+	for _, file := range o.HelmValues {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		valuesFiles = append(valuesFiles, o.variables.Escape(content))
+	}
+	vals, err := helm.MergeValues(valuesFiles...)
+	if err != nil {
+		return err
+	}
+	vals, err = helm.ChartValues(chartPath, vals)
+	if err != nil {
+		return err
+	}
+
+	o.customizedVariables = nil
+	for _, exposed := range o.ExposedValues {
+		name, path, err := parseExposedValue(exposed)
+		if err != nil {
+			return err
+		}
+		value, err := helm.StringValue(vals, path)
+		if err != nil {
+			return fmt.Errorf("failed to expose the value of the helm chart %s: %v", o.HelmChart, err)
+		}
+		str := o.variables.Restore(value).(string)
+		o.customizedVariables = append(o.customizedVariables, addonv1alpha1.CustomizedVariable{Name: name, Value: str})
+		if err := helm.SetValue(vals, path, o.variables.Placeholder(name)); err != nil {
+			return err
+		}
+	}
+
+	o.chartPath = chartPath
+	o.chartValues = vals
+	return nil
+}
+
+// renderChart renders the helm chart into the manifests with the variables restored.
+func (o *Options) renderChart() ([]workapiv1.Manifest, error) {
+	rendered, err := helm.RenderChartValues(o.chartPath, o.Name, o.HelmNamespace, o.chartValues)
+	if err != nil {
+		return nil, err
+	}
+	result := resource.NewLocalBuilder().
+		Unstructured().
+		Stream(strings.NewReader(rendered), o.HelmChart).
+		Flatten().
+		ContinueOnError().
+		Do()
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	items, err := result.Infos()
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := []workapiv1.Manifest{}
+	for _, item := range items {
+		obj, ok := item.Object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		obj.Object = o.variables.Restore(obj.Object).(map[string]interface{})
+		manifests = append(manifests, workapiv1.Manifest{RawExtension: runtime.RawExtension{Object: obj}})
+	}
+	return manifests, nil
+}
+
+// parseExposedValue parses the exposed value in the format of [{name}=]{path}, the name is the upper case of
+// the path with the dots replaced by underscores if it is not specified, e.g. IMAGE_TAG of image.tag.
+func parseExposedValue(value string) (name, path string, err error) {
+	name, path, found := strings.Cut(value, "=")
+	if !found {
+		path = name
+		name = strings.ToUpper(nonVariableCharacters.ReplaceAllString(path, "_"))
+	}
+	if len(path) == 0 {
+		return "", "", fmt.Errorf("the path of the exposed value %s must not be empty", value)
+	}
+	if !variableNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid variable name %s of the exposed value %s, should match %s", name, value, variableNamePattern)
+	}
+	return name, path, nil
+}
+
 func (o *Options) readManifests() ([]workapiv1.Manifest, error) {
+	if len(o.HelmChart) > 0 {
+		return o.renderChart()
+	}
+
 	opt := o.FileNameFlags.ToOptions()
 	builder := resource.NewLocalBuilder().
 		Unstructured().
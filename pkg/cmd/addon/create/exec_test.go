@@ -31,6 +31,27 @@ var _ = ginkgo.Describe("addon create", func() {
 
 	streams := genericiooptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr}
 
+	ginkgo.Context("parseExposedValue", func() {
+		ginkgo.It("Should parse the exposed value with a name", func() {
+			name, path, err := parseExposedValue("TAG=image.tag")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(name).To(gomega.Equal("TAG"))
+			gomega.Expect(path).To(gomega.Equal("image.tag"))
+		})
+
+		ginkgo.It("Should name the exposed value after the path", func() {
+			name, path, err := parseExposedValue("image.pull-policy")
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(name).To(gomega.Equal("IMAGE_PULL_POLICY"))
+			gomega.Expect(path).To(gomega.Equal("image.pull-policy"))
+		})
+
+		ginkgo.It("Should return error for an invalid name", func() {
+			_, _, err := parseExposedValue("1TAG=image.tag")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+	})
+
 	ginkgo.Context("parsePlacementRef", func() {
 		ginkgo.It("Should parse valid placement-ref correctly", func() {
 			o := &Options{
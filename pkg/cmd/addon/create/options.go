@@ -6,7 +6,9 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/utils/ptr"
 
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	"open-cluster-management.io/clusteradm/pkg/helpers/helm"
 )
 
 type Options struct {
@@ -32,6 +34,38 @@ type Options struct {
 	PlacementRef string
 
 	FileNameFlags genericclioptions.FileNameFlags
+
+	// HelmChart is the local directory or archive of a helm chart, or a chart in a repository in the format of
+	// {repo}/{chart}, rendered into the manifests of the AddOnTemplate
+	HelmChart string
+
+	// HelmVersion is the version of the chart in a repository
+	HelmVersion string
+
+	// HelmValues are the values files of the chart, the variables like {{CLUSTER_NAME}} are kept in the manifests
+	HelmValues []string
+
+	// HelmNamespace is the release namespace to render the chart
+	HelmNamespace string
+
+	// ExposedValues are the values of the chart exposed as the customized variables of an AddOnDeploymentConfig,
+	// in the format of [{name}=]{path}
+	ExposedValues []string
+
+	// ConfigNamespace is the namespace of the AddOnDeploymentConfig of the exposed values
+	ConfigNamespace string
+
+	// chartPath is the local path of the chart
+	chartPath string
+
+	// chartValues are the values to render the chart, with the placeholders of the variables
+	chartValues map[string]interface{}
+
+	// variables are the variables replaced by the placeholders in the chart values
+	variables helm.Variables
+
+	// customizedVariables are the exposed values with the values of the chart
+	customizedVariables []addonv1alpha1.CustomizedVariable
 	//
 	Streams genericiooptions.IOStreams
 }
@@ -0,0 +1,64 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var example = `
+# Add a cluster claim to clusters
+%[1]s claim cluster cluster1 cluster2 region=us-east-1
+
+# Update a cluster claim of all the clusters in a clusterset
+%[1]s claim cluster --clusterset set1 region=us-west-1 --overwrite
+
+# Remove a cluster claim created by clusteradm
+%[1]s claim cluster cluster1 region-
+`
+
+// NewCmd...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:   "cluster [NAME...] CLAIM_1=VAL_1 ... CLAIM_N=VAL_N",
+		Short: "update the cluster claims of managed clusters",
+		Long: fmt.Sprintf("set or remove the ClusterClaims of managed clusters, a claim is removed with a trailing dash. "+
+			"The claims are delivered to the managed clusters by the ManifestWork %s, and reported back in the status "+
+			"of the ManagedClusters by the klusterlet agent", clusterhelpers.ClaimWorkName),
+		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.All, "all", false, "Update all the managed clusters")
+	cmd.Flags().StringVar(&o.ClusterSet, "clusterset", "", "Update all the managed clusters in the clusterset")
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", false, "If set, the value of an existing claim is overwritten")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "If set, the claims reserved by OCM are allowed to be changed")
+
+	return cmd
+}
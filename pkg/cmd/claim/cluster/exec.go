@@ -0,0 +1,104 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
+	var specs []string
+	o.Clusters, specs = clusterhelpers.SplitArgs(args)
+	if len(specs) == 0 {
+		return fmt.Errorf("at least one {claim}={value} or {claim}- must be specified")
+	}
+	o.set, o.remove, err = clusterhelpers.ParseKeyValues(specs, false)
+	if err != nil {
+		return err
+	}
+
+	// the name of a ClusterClaim is a DNS subdomain without a prefix
+	for _, name := range append(sets.List(sets.KeySet(o.set)), o.remove...) {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("invalid claim name %s: %s", name, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+func (o *Options) validate() error {
+	if err := o.ClusteradmFlags.ValidateHub(); err != nil {
+		return err
+	}
+
+	selectors := 0
+	for _, selected := range []bool{len(o.Clusters) > 0, o.All, len(o.ClusterSet) > 0} {
+		if selected {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return fmt.Errorf("exactly one of the cluster names, --clusterset or --all must be specified")
+	}
+
+	if !o.Force {
+		return clusterhelpers.CheckReservedKeys(o.set, o.remove, clusterhelpers.IsReservedClaim)
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	workClient, err := workclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	clusters, err := clusterhelpers.SelectClusters(ctx, clusterClient, o.Clusters, o.All, o.ClusterSet)
+	if err != nil {
+		return err
+	}
+	if len(clusters) == 0 {
+		fmt.Fprintf(o.Streams.Out, "No clusters found\n")
+		return nil
+	}
+
+	var errs []error
+	for _, name := range clusters {
+		cluster, err := clusterClient.ClusterV1().ManagedClusters().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		changed, err := clusterhelpers.UpdateClaims(ctx, workClient, cluster, o.set, o.remove, o.Overwrite, o.ClusteradmFlags.DryRun)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if changed {
+			fmt.Fprintf(o.Streams.Out, "Cluster %s claims updated\n", name)
+		} else {
+			fmt.Fprintf(o.Streams.Out, "Cluster %s claims not changed\n", name)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
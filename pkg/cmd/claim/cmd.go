@@ -0,0 +1,22 @@
+// Copyright Contributors to the Open Cluster Management project
+package claim
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/cmd/claim/cluster"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping the claim subcommands
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim",
+		Short: "update the cluster claims of managed clusters",
+	}
+
+	cmd.AddCommand(cluster.NewCmd(clusteradmFlags, streams))
+
+	return cmd
+}
@@ -14,6 +14,12 @@ import (
 var example = `
 # Bind a clusterset to a namespace
 %[1]s clusterset bind clusterset1 --namespace default
+
+# Bind a clusterset to several namespaces
+%[1]s clusterset bind clusterset1 --namespaces app1,app2,app3
+
+# Bind a clusterset to the namespaces with a label
+%[1]s clusterset bind clusterset1 --namespace-selector team=a
 `
 
 // NewCmd...
@@ -22,10 +28,11 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 
 	cmd := &cobra.Command{
 		Use:   "bind",
-		Short: "bind a clusterset to a namespace",
+		Short: "bind a clusterset to namespaces",
 		Long: "bind a clusterset to a namespace to make it a 'workspace namespace'. " +
 			"Note that the namespace SHALL NOT be an existing 'cluster namespace' " +
-			"(i.e. the namespace has the same name of a registered managed cluster).",
+			"(i.e. the namespace has the same name of a registered managed cluster). " +
+			"The permission to bind the clusterset is checked before creating the bindings.",
 		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
 		SilenceUsage: true,
 		PreRunE: func(c *cobra.Command, args []string) error {
@@ -48,7 +55,10 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 		},
 	}
 
-	cmd.Flags().StringVar(&o.Namespace, "namespace", "default", "Namespace to bind to a clusterset")
+	cmd.Flags().StringVar(&o.Namespace, "namespace", "default",
+		"Namespace to bind to a clusterset, ignored if --namespaces or --namespace-selector is set unless specified explicitly")
+	cmd.Flags().StringSliceVar(&o.Namespaces, "namespaces", o.Namespaces, "Namespaces to bind to a clusterset (comma separated)")
+	cmd.Flags().StringVar(&o.NamespaceSelector, "namespace-selector", "", "Label selector of the namespaces to bind to a clusterset")
 
 	return cmd
 }
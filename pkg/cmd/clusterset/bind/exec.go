@@ -4,16 +4,23 @@ package bind
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
 
 	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
 	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 )
 
-func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
+func (o *Options) complete(c *cobra.Command, args []string) (err error) {
 	if len(args) == 0 {
 		return fmt.Errorf("the name of the clusterset must be specified")
 	}
@@ -24,6 +31,12 @@ func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
 
 	o.Clusterset = args[0]
 
+	// --namespace defaults to the default namespace, which is not bound if the namespaces are selected
+	// by the other flags
+	if (len(o.Namespaces) > 0 || len(o.NamespaceSelector) > 0) && !c.Flags().Changed("namespace") {
+		o.Namespace = ""
+	}
+
 	return nil
 }
 
@@ -33,8 +46,18 @@ func (o *Options) Validate() (err error) {
 		return err
 	}
 
-	if len(o.Namespace) == 0 {
-		return fmt.Errorf("namespace name must be specified in --namespace")
+	if len(o.Namespace) == 0 && len(o.Namespaces) == 0 && len(o.NamespaceSelector) == 0 {
+		return fmt.Errorf("namespace name must be specified in --namespace, --namespaces or --namespace-selector")
+	}
+	for _, ns := range o.Namespaces {
+		if len(ns) == 0 {
+			return fmt.Errorf("--namespaces cannot be set as an empty value")
+		}
+	}
+	if len(o.NamespaceSelector) > 0 {
+		if _, err := labels.Parse(o.NamespaceSelector); err != nil {
+			return fmt.Errorf("failed to parse namespace selector %s: %v", o.NamespaceSelector, err)
+		}
 	}
 
 	return nil
@@ -49,25 +72,58 @@ func (o *Options) Run() (err error) {
 	if err != nil {
 		return err
 	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
 
-	_, err = clusterClient.ClusterV1beta2().ManagedClusterSets().Get(context.TODO(), o.Clusterset, metav1.GetOptions{})
+	ctx := context.TODO()
+	_, err = clusterClient.ClusterV1beta2().ManagedClusterSets().Get(ctx, o.Clusterset, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
 
+	namespaces, err := o.selectNamespaces(ctx, kubeClient, clusterClient)
+	if err != nil {
+		return err
+	}
+	if len(namespaces) == 0 {
+		fmt.Fprintf(o.Streams.Out, "No namespaces found to bind Clusterset %s\n", o.Clusterset)
+		return nil
+	}
+
+	if err := checkBindPermission(ctx, kubeClient, o.Clusterset, namespaces); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, namespace := range namespaces {
+		if err := o.bind(ctx, clusterClient, namespace); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *Options) bind(ctx context.Context, clusterClient clusterclientset.Interface, namespace string) error {
 	binding := &clusterapiv1beta2.ManagedClusterSetBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      o.Clusterset,
-			Namespace: o.Namespace,
+			Namespace: namespace,
 		},
 		Spec: clusterapiv1beta2.ManagedClusterSetBindingSpec{
 			ClusterSet: o.Clusterset,
 		},
 	}
 
-	_, err = clusterClient.ClusterV1beta2().ManagedClusterSetBindings(o.Namespace).Create(context.TODO(), binding, metav1.CreateOptions{})
+	if o.ClusteradmFlags.DryRun {
+		fmt.Fprintf(o.Streams.Out, "Clusterset %s is bound to Namespace %s\n", o.Clusterset, namespace)
+		return nil
+	}
+
+	_, err := clusterClient.ClusterV1beta2().ManagedClusterSetBindings(namespace).Create(ctx, binding, metav1.CreateOptions{})
 	if errors.IsAlreadyExists(err) {
-		fmt.Fprintf(o.Streams.Out, "Clusterset %s is already bound to Namespace %s\n", o.Clusterset, o.Namespace)
+		fmt.Fprintf(o.Streams.Out, "Clusterset %s is already bound to Namespace %s\n", o.Clusterset, namespace)
 		return nil
 	}
 
@@ -75,6 +131,89 @@ func (o *Options) Run() (err error) {
 		return err
 	}
 
-	fmt.Fprintf(o.Streams.Out, "Clusterset %s is bound to Namespace %s\n", o.Clusterset, o.Namespace)
+	fmt.Fprintf(o.Streams.Out, "Clusterset %s is bound to Namespace %s\n", o.Clusterset, namespace)
 	return nil
 }
+
+// selectNamespaces returns the namespaces specified by the flags. The cluster namespaces are skipped with
+// a warning, since they can not be workspace namespaces.
+func (o *Options) selectNamespaces(ctx context.Context, kubeClient kubernetes.Interface, clusterClient clusterclientset.Interface) ([]string, error) {
+	namespaces := sets.New[string](o.Namespaces...)
+	if len(o.Namespace) > 0 {
+		namespaces.Insert(o.Namespace)
+	}
+	if len(o.NamespaceSelector) > 0 {
+		nsList, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: o.NamespaceSelector})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range nsList.Items {
+			namespaces.Insert(ns.Name)
+		}
+	}
+
+	clusters, err := clusterClient.ClusterV1().ManagedClusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters.Items {
+		if namespaces.Has(cluster.Name) {
+			fmt.Fprintf(o.Streams.ErrOut, "Warning: Namespace %s is a cluster namespace, skipping\n", cluster.Name)
+			namespaces.Delete(cluster.Name)
+		}
+	}
+	return sets.List(namespaces), nil
+}
+
+// checkBindPermission checks the current user is allowed to bind the clusterset and create the bindings
+// in the namespaces, which is otherwise denied by the webhook with an opaque message.
+func checkBindPermission(ctx context.Context, kubeClient kubernetes.Interface, clusterset string, namespaces []string) error {
+	allowed, err := canI(ctx, kubeClient, &authorizationv1.ResourceAttributes{
+		Group:       clusterapiv1beta2.GroupName,
+		Resource:    "managedclustersets",
+		Subresource: "bind",
+		Verb:        "create",
+		Name:        clusterset,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("the current user is not allowed to bind clusterset %s, which requires the permission to "+
+			"create managedclustersets/bind of %s in the %s API group", clusterset, clusterset, clusterapiv1beta2.GroupName)
+	}
+
+	var denied []string
+	for _, namespace := range namespaces {
+		allowed, err := canI(ctx, kubeClient, &authorizationv1.ResourceAttributes{
+			Group:     clusterapiv1beta2.GroupName,
+			Resource:  "managedclustersetbindings",
+			Verb:      "create",
+			Namespace: namespace,
+		})
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			denied = append(denied, namespace)
+		}
+	}
+	if len(denied) > 0 {
+		sort.Strings(denied)
+		return fmt.Errorf("the current user is not allowed to create managedclustersetbindings in namespaces: %s",
+			strings.Join(denied, ","))
+	}
+	return nil
+}
+
+func canI(ctx context.Context, kubeClient kubernetes.Interface, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	review, err := kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,65 @@
+// Copyright Contributors to the Open Cluster Management project
+package bind
+
+import (
+	"context"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestCheckBindPermission(t *testing.T) {
+	cases := []struct {
+		name       string
+		allowed    sets.Set[string]
+		namespaces []string
+		expectErr  bool
+	}{
+		{
+			name:       "allowed",
+			allowed:    sets.New[string]("managedclustersets/bind", "managedclustersetbindings/ns1", "managedclustersetbindings/ns2"),
+			namespaces: []string{"ns1", "ns2"},
+		},
+		{
+			name:       "not allowed to bind",
+			allowed:    sets.New[string]("managedclustersetbindings/ns1"),
+			namespaces: []string{"ns1"},
+			expectErr:  true,
+		},
+		{
+			name:       "not allowed to create the bindings in a namespace",
+			allowed:    sets.New[string]("managedclustersets/bind", "managedclustersetbindings/ns1"),
+			namespaces: []string{"ns1", "ns2"},
+			expectErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "selfsubjectaccessreviews",
+				func(action clienttesting.Action) (bool, runtime.Object, error) {
+					review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+					attributes := review.Spec.ResourceAttributes
+					key := attributes.Resource + "/" + attributes.Subresource
+					if len(attributes.Namespace) > 0 {
+						key = attributes.Resource + "/" + attributes.Namespace
+					}
+					review.Status.Allowed = c.allowed.Has(key)
+					return true, review, nil
+				})
+
+			err := checkBindPermission(context.TODO(), kubeClient, "set1", c.namespaces)
+			if err != nil && !c.expectErr {
+				t.Errorf("should not have error, but got %v", err)
+			}
+			if err == nil && c.expectErr {
+				t.Errorf("should return err")
+			}
+		})
+	}
+}
@@ -16,6 +16,10 @@ type Options struct {
 	Clusterset string
 
 	Namespace string
+
+	// Namespaces and NamespaceSelector select more namespaces to bind the clusterset to
+	Namespaces        []string
+	NamespaceSelector string
 }
 
 func NewOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
@@ -6,6 +6,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 
 	"open-cluster-management.io/clusteradm/pkg/cmd/clusterset/bind"
+	"open-cluster-management.io/clusteradm/pkg/cmd/clusterset/namespaces"
 	"open-cluster-management.io/clusteradm/pkg/cmd/clusterset/set"
 	"open-cluster-management.io/clusteradm/pkg/cmd/clusterset/unbind"
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
@@ -16,12 +17,13 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 	cmd := &cobra.Command{
 		Use:   "clusterset",
 		Short: "clusterset options",
-		Long:  "there are 3 clusterset options: set, bind and unbind",
+		Long:  "there are 4 clusterset options: set, bind, unbind and namespaces",
 	}
 
 	cmd.AddCommand(set.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(bind.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(unbind.NewCmd(clusteradmFlags, streams))
+	cmd.AddCommand(namespaces.NewCmd(clusteradmFlags, streams))
 
 	return cmd
 }
@@ -0,0 +1,57 @@
+// Copyright Contributors to the Open Cluster Management project
+package add
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var example = `
+# Add managed namespaces to a clusterset
+%[1]s clusterset namespaces add clusterset1 ns1 ns2
+
+# Add a managed namespace and wait until it is created on all the clusters of the clusterset
+%[1]s clusterset namespaces add clusterset1 ns1 --wait --timeout 60
+`
+
+// NewCmd...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:   "add CLUSTERSET NAMESPACE...",
+		Short: "add managed namespaces to a clusterset",
+		Long: "add managed namespaces to a clusterset, they are created on every member cluster. " +
+			"A warning is printed for the namespaces failed on the clusters",
+		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Wait, "wait", false,
+		"Wait until the namespaces are available on all the clusters of the clusterset, and fail if any of them fails or --timeout is reached")
+
+	return cmd
+}
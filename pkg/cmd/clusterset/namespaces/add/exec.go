@@ -0,0 +1,121 @@
+// Copyright Contributors to the Open Cluster Management project
+package add
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
+	if len(args) < 2 {
+		return fmt.Errorf("the name of the clusterset and at least one namespace must be specified")
+	}
+	o.Clusterset = args[0]
+	o.Namespaces, err = clusterhelpers.ValidateNamespaceNames(args[1:])
+	return err
+}
+
+func (o *Options) validate() error {
+	return o.ClusteradmFlags.ValidateHub()
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	clusterSet, added, _, err := clusterhelpers.UpdateManagedNamespaces(ctx, clusterClient, o.Clusterset, o.Namespaces, nil, o.ClusteradmFlags.DryRun)
+	if err != nil {
+		return err
+	}
+	for _, namespace := range o.Namespaces {
+		if slices.Contains(added, namespace) {
+			fmt.Fprintf(o.Streams.Out, "Managed namespace %s is added to Clusterset %s\n", namespace, o.Clusterset)
+		} else {
+			fmt.Fprintf(o.Streams.Out, "Managed namespace %s already exists in Clusterset %s\n", namespace, o.Clusterset)
+		}
+	}
+	if o.ClusteradmFlags.DryRun {
+		return nil
+	}
+
+	if !o.Wait {
+		statuses, err := namespaceStatuses(ctx, clusterClient, clusterSet, o.Namespaces)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			if status.State == clusterhelpers.NamespaceFailed {
+				fmt.Fprintf(o.Streams.ErrOut, "Warning: managed namespace %s failed on cluster %s: %s %s\n",
+					status.Namespace, status.Cluster, status.Reason, status.Message)
+			}
+		}
+		return nil
+	}
+	return o.waitForAvailable(ctx, clusterClient, clusterSet)
+}
+
+// waitForAvailable waits until the namespaces are available on all the member clusters. It returns an error
+// once a namespace fails on a cluster, or the namespaces are still pending when the timeout is reached.
+func (o *Options) waitForAvailable(ctx context.Context, clusterClient clusterclientset.Interface, clusterSet *clusterv1beta2.ManagedClusterSet) error {
+	var pending, failed []string
+	timeout := time.Duration(o.ClusteradmFlags.Timeout) * time.Second
+	err := wait.PollUntilContextTimeout(ctx, o.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		statuses, err := namespaceStatuses(ctx, clusterClient, clusterSet, o.Namespaces)
+		if err != nil {
+			return false, err
+		}
+		pending, failed = nil, nil
+		for _, status := range statuses {
+			switch status.State {
+			case clusterhelpers.NamespaceFailed:
+				failed = append(failed, fmt.Sprintf("%s on cluster %s: %s %s", status.Namespace, status.Cluster, status.Reason, status.Message))
+			case clusterhelpers.NamespacePending:
+				pending = append(pending, fmt.Sprintf("%s on cluster %s", status.Namespace, status.Cluster))
+			}
+		}
+		return len(failed) > 0 || len(pending) == 0, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timeout waiting for the managed namespaces, still pending: %s", strings.Join(pending, "; "))
+	}
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("managed namespaces failed: %s", strings.Join(failed, "; "))
+	}
+
+	fmt.Fprintf(o.Streams.Out, "Managed namespaces %s are available on all the clusters of Clusterset %s\n",
+		strings.Join(o.Namespaces, ","), o.Clusterset)
+	return nil
+}
+
+// namespaceStatuses returns the status of the namespaces on the current member clusters of the clusterset.
+func namespaceStatuses(
+	ctx context.Context,
+	clusterClient clusterclientset.Interface,
+	clusterSet *clusterv1beta2.ManagedClusterSet,
+	namespaces []string) ([]clusterhelpers.NamespaceStatus, error) {
+	clusters, err := clusterhelpers.ClustersOfClusterSet(ctx, clusterClient, clusterSet)
+	if err != nil {
+		return nil, err
+	}
+	return clusterhelpers.ManagedNamespaceStatuses(clusterSet.Name, namespaces, clusters), nil
+}
@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package add
+
+import (
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	Streams genericiooptions.IOStreams
+
+	Clusterset string
+
+	Namespaces []string
+
+	// Wait waits until the namespaces are available on all the member clusters, and fails if they are not
+	Wait bool
+
+	// pollInterval is the interval to check the managed namespaces reported by the clusters
+	pollInterval time.Duration
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+		pollInterval:    2 * time.Second,
+	}
+}
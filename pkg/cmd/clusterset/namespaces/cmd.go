@@ -0,0 +1,28 @@
+// Copyright Contributors to the Open Cluster Management project
+package namespaces
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/cmd/clusterset/namespaces/add"
+	"open-cluster-management.io/clusteradm/pkg/cmd/clusterset/namespaces/list"
+	"open-cluster-management.io/clusteradm/pkg/cmd/clusterset/namespaces/remove"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping the managed namespaces subcommands
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "namespaces",
+		Short: "manage the namespaces created on the clusters of a clusterset",
+		Long: "the managed namespaces of a clusterset are created on every member cluster by the registration agent, " +
+			"which reports their status in the status of the managed cluster",
+	}
+
+	cmd.AddCommand(add.NewCmd(clusteradmFlags, streams))
+	cmd.AddCommand(remove.NewCmd(clusteradmFlags, streams))
+	cmd.AddCommand(list.NewCmd(clusteradmFlags, streams))
+
+	return cmd
+}
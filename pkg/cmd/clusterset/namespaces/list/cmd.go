@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var example = `
+# List the managed namespaces of a clusterset with their status on each cluster
+%[1]s clusterset namespaces list clusterset1
+
+# List only the managed namespaces failed on the clusters
+%[1]s clusterset namespaces list clusterset1 --failed-only
+`
+
+// NewCmd...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:   "list CLUSTERSET",
+		Short: "list the managed namespaces of a clusterset",
+		Long: "list the managed namespaces of a clusterset with their status on each member cluster, " +
+			"as reported in the status of the managed clusters",
+		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.FailedOnly, "failed-only", false, "List only the namespaces failed on the clusters")
+
+	o.printer.AddFlag(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,131 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("the name of the clusterset must be specified")
+	}
+	o.Clusterset = args[0]
+
+	o.printer.Competele()
+
+	return nil
+}
+
+func (o *Options) validate() error {
+	if err := o.ClusteradmFlags.ValidateHub(); err != nil {
+		return err
+	}
+	return o.printer.Validate()
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	o.clusterSet, err = clusterClient.ClusterV1beta2().ManagedClusterSets().Get(ctx, o.Clusterset, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if len(o.clusterSet.Spec.ManagedNamespaces) == 0 {
+		fmt.Fprintf(o.Streams.Out, "Clusterset %s has no managed namespaces\n", o.Clusterset)
+		return nil
+	}
+
+	clusters, err := clusterhelpers.ClustersOfClusterSet(ctx, clusterClient, o.clusterSet)
+	if err != nil {
+		return err
+	}
+	clusterList := &clusterv1.ManagedClusterList{}
+	for _, cluster := range clusters {
+		clusterList.Items = append(clusterList.Items, *cluster)
+	}
+
+	o.printer.WithTreeConverter(o.convertToTree).WithTableConverter(o.convertToTable)
+	return o.printer.Print(o.Streams, clusterList)
+}
+
+// statuses returns the status of the managed namespaces of the clusterset on the clusters in the list.
+func (o *Options) statuses(obj runtime.Object) []clusterhelpers.NamespaceStatus {
+	clusterList, ok := obj.(*clusterv1.ManagedClusterList)
+	if !ok {
+		return nil
+	}
+	var clusters []*clusterv1.ManagedCluster
+	for i := range clusterList.Items {
+		clusters = append(clusters, &clusterList.Items[i])
+	}
+	var namespaces []string
+	for _, ns := range o.clusterSet.Spec.ManagedNamespaces {
+		namespaces = append(namespaces, ns.Name)
+	}
+
+	var statuses []clusterhelpers.NamespaceStatus
+	for _, status := range clusterhelpers.ManagedNamespaceStatuses(o.Clusterset, namespaces, clusters) {
+		if o.FailedOnly && status.State != clusterhelpers.NamespaceFailed {
+			continue
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (o *Options) convertToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
+	namespaces := map[string]map[string]interface{}{}
+	var order []string
+	for _, status := range o.statuses(obj) {
+		if _, ok := namespaces[status.Namespace]; !ok {
+			namespaces[status.Namespace] = map[string]interface{}{}
+			order = append(order, status.Namespace)
+		}
+		namespaces[status.Namespace]["."+status.Cluster] = fmt.Sprintf("%s %s", status.State, status.Reason)
+	}
+	for _, namespace := range order {
+		mp := namespaces[namespace]
+		tree.AddFileds(namespace, &mp)
+	}
+	return tree
+}
+
+func (o *Options) convertToTable(obj runtime.Object) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Namespace", Type: "string"},
+			{Name: "Cluster", Type: "string"},
+			{Name: "Status", Type: "string"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+		Rows: []metav1.TableRow{},
+	}
+
+	for _, status := range o.statuses(obj) {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells:  []interface{}{status.Namespace, status.Cluster, status.State, status.Reason, status.Message},
+			Object: runtime.RawExtension{Object: o.clusterSet},
+		})
+	}
+
+	return table
+}
@@ -0,0 +1,51 @@
+// Copyright Contributors to the Open Cluster Management project
+package list
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	Streams genericiooptions.IOStreams
+
+	printer *printer.PrinterOption
+
+	Clusterset string
+
+	// FailedOnly lists only the namespaces failed on the clusters
+	FailedOnly bool
+
+	clusterSet *clusterv1beta2.ManagedClusterSet
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+		printer:         printer.NewPrinterOption(pntOpt).WithDefaultFormat("table"),
+	}
+}
+
+var pntOpt = printers.PrintOptions{
+	NoHeaders:     false,
+	WithNamespace: false,
+	WithKind:      false,
+	Wide:          false,
+	ShowLabels:    false,
+	Kind: schema.GroupKind{
+		Group: "cluster.open-cluster-management.io",
+		Kind:  "ManagedClusterSet",
+	},
+	ColumnLabels:     []string{},
+	SortBy:           "",
+	AllowMissingKeys: true,
+}
@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+package remove
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
+	if len(args) < 2 {
+		return fmt.Errorf("the name of the clusterset and at least one namespace must be specified")
+	}
+	o.Clusterset = args[0]
+	o.Namespaces, err = clusterhelpers.ValidateNamespaceNames(args[1:])
+	return err
+}
+
+func (o *Options) validate() error {
+	return o.ClusteradmFlags.ValidateHub()
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	_, _, removed, err := clusterhelpers.UpdateManagedNamespaces(context.TODO(), clusterClient, o.Clusterset, nil, o.Namespaces, o.ClusteradmFlags.DryRun)
+	if err != nil {
+		return err
+	}
+	for _, namespace := range removed {
+		fmt.Fprintf(o.Streams.Out, "Managed namespace %s is removed from Clusterset %s\n", namespace, o.Clusterset)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// Copyright Contributors to the Open Cluster Management project
+package remove
+
+import (
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	Streams genericiooptions.IOStreams
+
+	Clusterset string
+
+	Namespaces []string
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+	}
+}
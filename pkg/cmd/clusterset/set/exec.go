@@ -4,14 +4,15 @@ package set
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
-	clusterapiv1 "open-cluster-management.io/api/cluster/v1"
 	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
 )
 
 func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
@@ -56,27 +57,32 @@ func (o *Options) Run() (err error) {
 		return err
 	}
 
+	if len(o.Clusters) > 0 && clusterSet.Spec.ClusterSelector.SelectorType == clusterv1beta2.LabelSelector {
+		return fmt.Errorf("clusterset %s selects the clusters by the label selector %s rather than the clusterset label, "+
+			"label the clusters to match the selector instead, e.g. with 'clusteradm label cluster'",
+			o.Clusterset, metav1.FormatLabelSelector(clusterSet.Spec.ClusterSelector.LabelSelector))
+	}
+
 	for _, clusterName := range o.Clusters {
 		cluster, err := clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), clusterName, metav1.GetOptions{})
 		if err != nil {
 			return err
 		}
 
-		if len(cluster.Labels) == 0 {
-			cluster.Labels = map[string]string{}
-		}
-
-		clusterset := cluster.Labels["cluster.open-cluster-management.io/clusterset"]
+		clusterset := cluster.Labels[clusterv1beta2.ClusterSetLabel]
 		if clusterset == o.Clusterset {
 			fmt.Fprintf(o.Streams.Out, "Cluster %s is already in Clusterset %s\n", clusterName, o.Clusterset)
 			continue
 		}
 
-		cluster.Labels["cluster.open-cluster-management.io/clusterset"] = o.Clusterset
-		_, err = clusterClient.ClusterV1().ManagedClusters().Update(context.TODO(), cluster, metav1.UpdateOptions{})
+		_, err = clusterhelpers.PatchMetadata(context.TODO(), clusterClient, clusterName, clusterhelpers.FieldLabels,
+			map[string]string{clusterv1beta2.ClusterSetLabel: o.Clusterset}, nil, true, o.ClusteradmFlags.DryRun)
 		if err != nil {
 			return err
 		}
+		if o.ClusteradmFlags.DryRun {
+			continue
+		}
 
 		if len(clusterset) == 0 {
 			fmt.Fprintf(o.Streams.Out, "Cluster %s is set to Clusterset %s\n", clusterName, o.Clusterset)
@@ -86,7 +92,7 @@ func (o *Options) Run() (err error) {
 	}
 
 	if len(o.Namespaces) > 0 {
-		if err := o.handleManagedNamespaces(clusterClient, clusterSet); err != nil {
+		if err := o.handleManagedNamespaces(clusterClient); err != nil {
 			return err
 		}
 	}
@@ -94,69 +100,38 @@ func (o *Options) Run() (err error) {
 	return nil
 }
 
-func (o *Options) handleManagedNamespaces(clusterClient *clusterclientset.Clientset, clusterSet *clusterv1beta2.ManagedClusterSet) error {
+// handleManagedNamespaces adds the managed namespaces to the clusterset, see 'clusterset namespaces' to
+// remove and list them.
+func (o *Options) handleManagedNamespaces(clusterClient clusterclientset.Interface) error {
 	fmt.Fprintf(o.Streams.Out, "Processing managed namespaces: %v\n", o.Namespaces)
 
-	// Initialize ManagedNamespaces slice if nil
-	if clusterSet.Spec.ManagedNamespaces == nil {
-		clusterSet.Spec.ManagedNamespaces = []clusterapiv1.ManagedNamespaceConfig{}
+	namespaces, err := clusterhelpers.ValidateNamespaceNames(o.Namespaces)
+	if err != nil {
+		return err
 	}
-
-	// Build lookup map of existing namespace names
-	existingMap := make(map[string]struct{}, len(clusterSet.Spec.ManagedNamespaces))
-	for _, ns := range clusterSet.Spec.ManagedNamespaces {
-		existingMap[ns.Name] = struct{}{}
+	updatedClusterSet, addedNamespaces, _, err := clusterhelpers.UpdateManagedNamespaces(
+		context.TODO(), clusterClient, o.Clusterset, namespaces, nil, o.ClusteradmFlags.DryRun)
+	if err != nil {
+		return fmt.Errorf("failed to update clusterset with managed namespaces: %v", err)
 	}
 
-	var addedNamespaces []string
-	var existingNamespaces []string
-
-	for _, namespace := range o.Namespaces {
-		namespace = strings.TrimSpace(namespace)
-		// Validate namespace name (basic validation)
-		if len(namespace) == 0 {
-			fmt.Fprintf(o.Streams.ErrOut, "Warning: Empty namespace name provided, skipping\n")
-			continue
-		}
-
-		// Check if namespace already exists
-		if _, exists := existingMap[namespace]; exists {
-			existingNamespaces = append(existingNamespaces, namespace)
+	for _, namespace := range namespaces {
+		if !slices.Contains(addedNamespaces, namespace) {
 			fmt.Fprintf(o.Streams.Out, "Managed namespace %s already exists in Clusterset %s\n", namespace, o.Clusterset)
-			continue
 		}
-
-		// Add the new managed namespace
-		clusterSet.Spec.ManagedNamespaces = append(clusterSet.Spec.ManagedNamespaces,
-			clusterapiv1.ManagedNamespaceConfig{
-				Name: namespace,
-			})
-
-		existingMap[namespace] = struct{}{}
-		addedNamespaces = append(addedNamespaces, namespace)
 	}
-	if len(addedNamespaces) > 0 {
-		// Update the clusterset with new managed namespaces
-		updatedClusterSet, err := clusterClient.ClusterV1beta2().ManagedClusterSets().Update(
-			context.TODO(),
-			clusterSet,
-			metav1.UpdateOptions{})
-		if err != nil {
-			return fmt.Errorf("failed to update clusterset with managed namespaces: %v", err)
-		}
-
-		// Print success messages
-		fmt.Fprintf(o.Streams.Out, "\nSuccessfully updated Clusterset %s\n", o.Clusterset)
-		fmt.Fprintf(o.Streams.Out, "Added %d managed namespace(s): %s\n",
-			len(addedNamespaces), strings.Join(addedNamespaces, ", "))
-
-		// Show total managed namespaces
-		fmt.Fprintf(o.Streams.Out, "Total managed namespaces in clusterset: %d\n",
-			len(updatedClusterSet.Spec.ManagedNamespaces))
-	} else if len(existingNamespaces) > 0 {
+	if len(addedNamespaces) == 0 {
 		fmt.Fprintf(o.Streams.Out, "\nAll specified namespaces already exist in Clusterset %s\n", o.Clusterset)
-	} else {
-		fmt.Fprintf(o.Streams.Out, "\nNo changes made to Clusterset %s\n", o.Clusterset)
+		return nil
 	}
+
+	// Print success messages
+	fmt.Fprintf(o.Streams.Out, "\nSuccessfully updated Clusterset %s\n", o.Clusterset)
+	fmt.Fprintf(o.Streams.Out, "Added %d managed namespace(s): %s\n",
+		len(addedNamespaces), strings.Join(addedNamespaces, ", "))
+
+	// Show total managed namespaces
+	fmt.Fprintf(o.Streams.Out, "Total managed namespaces in clusterset: %d\n",
+		len(updatedClusterSet.Spec.ManagedNamespaces))
 	return nil
 }
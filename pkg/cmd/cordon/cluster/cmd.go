@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var cordonExample = `
+# Mark clusters as unschedulable, placements not tolerating the taint stop selecting them
+%[1]s cordon cluster cluster1 cluster2
+`
+
+var uncordonExample = `
+# Mark clusters as schedulable again
+%[1]s uncordon cluster cluster1 cluster2
+`
+
+// NewCmd returns the command to cordon clusters, or to uncordon them if cordon is false.
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams, cordon bool) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams, cordon)
+
+	cmd := &cobra.Command{
+		Use:   "cluster NAME...",
+		Short: "mark managed clusters as unschedulable",
+		Long: fmt.Sprintf("add the %s:NoSelect taint to the managed clusters, so placements not tolerating it "+
+			"stop selecting them", clusterhelpers.CordonTaintKey),
+		Example:      fmt.Sprintf(cordonExample, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+	if !cordon {
+		cmd.Short = "mark managed clusters as schedulable"
+		cmd.Long = fmt.Sprintf("remove the %s:NoSelect taint from the managed clusters", clusterhelpers.CordonTaintKey)
+		cmd.Example = fmt.Sprintf(uncordonExample, clusteradmhelpers.GetExampleHeader())
+	}
+
+	return cmd
+}
@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("at least one cluster must be specified")
+	}
+	o.Clusters = args
+	return nil
+}
+
+func (o *Options) validate() error {
+	return o.ClusteradmFlags.ValidateHub()
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, cluster := range o.Clusters {
+		if err := o.setCordon(context.TODO(), clusterClient, cluster); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *Options) setCordon(ctx context.Context, clusterClient clusterclientset.Interface, name string) error {
+	changed, err := clusterhelpers.Cordon(ctx, clusterClient, name, o.cordon, o.ClusteradmFlags.DryRun)
+	if err != nil {
+		return err
+	}
+
+	action := "cordoned"
+	if !o.cordon {
+		action = "uncordoned"
+	}
+	if !changed {
+		fmt.Fprintf(o.Streams.Out, "Cluster %s is already %s\n", name, action)
+		return nil
+	}
+	fmt.Fprintf(o.Streams.Out, "Cluster %s is %s\n", name, action)
+	return nil
+}
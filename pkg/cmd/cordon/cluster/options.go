@@ -0,0 +1,28 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	Streams genericiooptions.IOStreams
+
+	Clusters []string
+
+	// cordon is true to add the cordon taint, and false to remove it
+	cordon bool
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams, cordon bool) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+		cordon:          cordon,
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package cordon
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/cmd/cordon/cluster"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping the cordon subcommands
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cordon",
+		Short: "mark managed clusters as unschedulable by placements",
+	}
+
+	cmd.AddCommand(cluster.NewCmd(clusteradmFlags, streams, true))
+
+	return cmd
+}
+
+// NewUncordonCmd provides a cobra command wrapping the uncordon subcommands
+func NewUncordonCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uncordon",
+		Short: "mark managed clusters as schedulable by placements",
+	}
+
+	cmd.AddCommand(cluster.NewCmd(clusteradmFlags, streams, false))
+
+	return cmd
+}
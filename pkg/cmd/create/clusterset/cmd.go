@@ -9,11 +9,16 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	clusterapiv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
 )
 
 var example = `
 # Create a clusterset
 %[1]s create clusterset clusterset1
+
+# Create a clusterset selecting the clusters by labels
+%[1]s create clusterset prod --selector-type LabelSelector --selector env=prod
 `
 
 // NewCmd...
@@ -21,9 +26,10 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 	o := NewOptions(clusteradmFlags, streams)
 
 	cmd := &cobra.Command{
-		Use:          "clusterset",
-		Short:        "create a clusterset",
-		Long:         "create a clusterset, by default created cluster set will be empty",
+		Use:   "clusterset",
+		Short: "create a clusterset",
+		Long: "create a clusterset, by default created cluster set will be empty. A clusterset with the LabelSelector " +
+			"selector type contains the clusters matching the selector",
 		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
 		SilenceUsage: true,
 		PreRunE: func(c *cobra.Command, args []string) error {
@@ -46,5 +52,10 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 		},
 	}
 
+	cmd.Flags().StringVar(&o.SelectorType, "selector-type", string(clusterapiv1beta2.ExclusiveClusterSetLabel),
+		"The type of the cluster selector, ExclusiveClusterSetLabel selects the clusters with the clusterset label, "+
+			"and LabelSelector selects the clusters by --selector")
+	cmd.Flags().StringVar(&o.Selector, "selector", "", "The label selector of the clusters, required with the LabelSelector selector type")
+
 	return cmd
 }
@@ -31,6 +31,22 @@ func (o *Options) Validate() (err error) {
 		return fmt.Errorf("only one clusterset can be created")
 	}
 
+	switch clusterapiv1beta2.SelectorType(o.SelectorType) {
+	case clusterapiv1beta2.ExclusiveClusterSetLabel:
+		if len(o.Selector) > 0 {
+			return fmt.Errorf("--selector is only supported with the LabelSelector selector type")
+		}
+	case clusterapiv1beta2.LabelSelector:
+		if len(o.Selector) == 0 {
+			return fmt.Errorf("--selector is required with the LabelSelector selector type")
+		}
+		if _, err := metav1.ParseToLabelSelector(o.Selector); err != nil {
+			return fmt.Errorf("failed to parse selector %s: %v", o.Selector, err)
+		}
+	default:
+		return fmt.Errorf("selector type %s is not supported, should be ExclusiveClusterSetLabel or LabelSelector", o.SelectorType)
+	}
+
 	return nil
 }
 
@@ -69,6 +85,16 @@ func (o *Options) runWithClient(clusterClient clusterclientset.Interface,
 			Name: clusterset,
 		},
 	}
+	if clusterapiv1beta2.SelectorType(o.SelectorType) == clusterapiv1beta2.LabelSelector {
+		selector, err := metav1.ParseToLabelSelector(o.Selector)
+		if err != nil {
+			return err
+		}
+		mcs.Spec.ClusterSelector = clusterapiv1beta2.ManagedClusterSelector{
+			SelectorType:  clusterapiv1beta2.LabelSelector,
+			LabelSelector: selector,
+		}
+	}
 
 	_, err = clusterClient.ClusterV1beta2().ManagedClusterSets().Create(context.TODO(), mcs, metav1.CreateOptions{})
 	if err != nil {
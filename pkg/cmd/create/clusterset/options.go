@@ -14,6 +14,12 @@ type Options struct {
 	Streams genericiooptions.IOStreams
 
 	Clustersets []string
+
+	// SelectorType is ExclusiveClusterSetLabel or LabelSelector
+	SelectorType string
+
+	// Selector is the label selector of the clusters in a LabelSelector clusterset
+	Selector string
 }
 
 func NewOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
@@ -23,12 +23,28 @@ var example = `
 
 # Create a placement with clustersets prioritizers
 %[1]s create placement test --prioritizers BuiltIn:Steady:3,BuiltIn:ResourceAllocatableCPU:2
+
+# Create a placement selecting the clusters by claims, tolerating the unreachable taint for 5 minutes
+%[1]s create placement test --claim-selectors platform.open-cluster-management.io=AWS \
+  --tolerations cluster.open-cluster-management.io/unreachable:NoSelect:300
+
+# Create a placement spreading the clusters across regions, with a canary decision group
+%[1]s create placement test --spread-constraints Claim:region.open-cluster-management.io:1 \
+  --decision-groups canary:env=canary --clusters-per-decision-group 25%%
+
+# Create a placement from a spec file, and add more predicates by flags
+%[1]s create placement test --spec-file placement-spec.yaml --cel-selectors 'managedCluster.metadata.labels["env"] == "prod"'
+
+# Simulate a placement to show the clusters it would select and their scores, without creating it
+%[1]s create placement test --clustersets set1 --count 2 --prioritizers BuiltIn:ResourceAllocatableMemory:1 --simulate
 `
 
 // NewCmd...
 func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
-	o := newOptions(clusteradmFlags, streams)
+	return newCmd(newOptions(clusteradmFlags, streams))
+}
 
+func newCmd(o *Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "placement",
 		Short:        "create a placement",
@@ -36,7 +52,7 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
 		SilenceUsage: true,
 		PreRunE: func(c *cobra.Command, args []string) error {
-			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+			clusteradmhelpers.DryRunMessage(o.ClusteradmFlags.DryRun)
 
 			return nil
 		},
@@ -61,6 +77,19 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 	cmd.Flags().StringSliceVar(&o.ClusterSets, "clustersets", o.ClusterSets, "Cluster Sets where clusters are selected")
 	cmd.Flags().StringSliceVar(&o.Prioritizers, "prioritizers", o.Prioritizers, "Prioritizers to sort and filter clusters")
 	cmd.Flags().Int32Var(&o.NumOfClusters, "count", o.NumOfClusters, "Number of clusters to select")
+	cmd.Flags().StringArrayVar(&o.ClaimSelectors, "claim-selectors", o.ClaimSelectors, "Cluster claim selectors to select clusters, each is added as a predicate")
+	cmd.Flags().StringArrayVar(&o.CelSelectors, "cel-selectors", o.CelSelectors, "CEL expressions to select clusters, each is added as a predicate")
+	cmd.Flags().StringSliceVar(&o.Tolerations, "tolerations", o.Tolerations,
+		"Tolerations of the cluster taints, in the format of {key}[={value}][:{effect}[:{tolerationSeconds}]]")
+	cmd.Flags().StringSliceVar(&o.SpreadConstraints, "spread-constraints", o.SpreadConstraints,
+		"Spread constraints of the selected clusters, in the format of {Label|Claim}:{topologyKey}[:{maxSkew}[:{DoNotSchedule|ScheduleAnyway}]]")
+	cmd.Flags().StringArrayVar(&o.DecisionGroups, "decision-groups", o.DecisionGroups,
+		"Decision groups of the selected clusters, in the format of {groupName}:{labelSelector}")
+	cmd.Flags().StringVar(&o.ClustersPerDecisionGroup, "clusters-per-decision-group", "",
+		"The number or percentage of the selected clusters in each decision group")
+	cmd.Flags().StringVar(&o.SpecFile, "spec-file", "", "The file of the placement or its spec, the other flags are added to the spec")
+	cmd.Flags().BoolVar(&o.Simulate, "simulate", false,
+		"Evaluate the placement against the current clusters and print the clusters it would select with their scores, without creating it")
 
 	return cmd
 }
@@ -2,18 +2,27 @@
 package placement
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
 	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	placementhelpers "open-cluster-management.io/clusteradm/pkg/helpers/placement"
 )
 
 func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
@@ -40,6 +49,11 @@ func (o *Options) validate() error {
 }
 
 func (o *Options) run() (err error) {
+	desiredPlacement, err := o.buildPlacement()
+	if err != nil {
+		return err
+	}
+
 	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
 	if err != nil {
 		return err
@@ -49,6 +63,23 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
+	for _, clusterset := range desiredPlacement.Spec.ClusterSets {
+		_, err := clusterClient.ClusterV1beta2().ManagedClusterSetBindings(o.Namespace).Get(context.TODO(), clusterset, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.Simulate {
+		return o.simulate(clusterClient, desiredPlacement)
+	}
+
+	return o.applyPlacement(clusterClient, desiredPlacement)
+}
+
+// buildPlacement builds the placement from the spec file and the flags, the flags are added to the spec
+// in the file. The spec is validated before being sent to the hub.
+func (o *Options) buildPlacement() (*clusterv1beta1.Placement, error) {
 	desiredPlacement := &clusterv1beta1.Placement{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      o.Placement,
@@ -56,50 +87,274 @@ func (o *Options) run() (err error) {
 		},
 	}
 
-	if len(o.ClusterSets) > 0 {
-		for _, clusterset := range o.ClusterSets {
-			_, err := clusterClient.ClusterV1beta2().ManagedClusterSetBindings(o.Namespace).Get(context.TODO(), clusterset, metav1.GetOptions{})
-			if err != nil {
-				return err
-			}
+	if len(o.SpecFile) > 0 {
+		spec, err := readSpecFile(o.SpecFile)
+		if err != nil {
+			return nil, err
 		}
-		desiredPlacement.Spec.ClusterSets = o.ClusterSets
+		desiredPlacement.Spec = *spec
+	}
+	spec := &desiredPlacement.Spec
+
+	if len(o.ClusterSets) > 0 {
+		spec.ClusterSets = append(spec.ClusterSets, o.ClusterSets...)
 	}
 
 	if o.NumOfClusters > 0 {
-		desiredPlacement.Spec.NumberOfClusters = ptr.To[int32](o.NumOfClusters)
+		spec.NumberOfClusters = ptr.To[int32](o.NumOfClusters)
 	}
 
-	if len(o.ClusterSelector) > 0 {
-		desiredPlacement.Spec.Predicates = []clusterv1beta1.ClusterPredicate{}
-		for _, s := range o.ClusterSelector {
-			selector, err := metav1.ParseToLabelSelector(s)
-			if err != nil {
-				return fmt.Errorf("failed to parse selector %s: %v", s, err)
-			}
-			desiredPlacement.Spec.Predicates = append(desiredPlacement.Spec.Predicates, clusterv1beta1.ClusterPredicate{
-				RequiredClusterSelector: clusterv1beta1.ClusterSelector{
-					LabelSelector: *selector,
-				},
-			})
+	for _, s := range o.ClusterSelector {
+		selector, err := metav1.ParseToLabelSelector(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse selector %s: %v", s, err)
+		}
+		spec.Predicates = append(spec.Predicates, clusterv1beta1.ClusterPredicate{
+			RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+				LabelSelector: *selector,
+			},
+		})
+	}
+
+	for _, s := range o.ClaimSelectors {
+		claimSelector, err := parseClaimSelector(s)
+		if err != nil {
+			return nil, err
 		}
+		spec.Predicates = append(spec.Predicates, clusterv1beta1.ClusterPredicate{
+			RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+				ClaimSelector: *claimSelector,
+			},
+		})
+	}
+
+	for _, expression := range o.CelSelectors {
+		spec.Predicates = append(spec.Predicates, clusterv1beta1.ClusterPredicate{
+			RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+				CelSelector: clusterv1beta1.ClusterCelSelector{CelExpressions: []string{expression}},
+			},
+		})
 	}
 
 	if len(o.Prioritizers) > 0 {
-		desiredPlacement.Spec.PrioritizerPolicy = clusterv1beta1.PrioritizerPolicy{
-			Mode:           clusterv1beta1.PrioritizerPolicyModeAdditive,
-			Configurations: []clusterv1beta1.PrioritizerConfig{},
+		if len(spec.PrioritizerPolicy.Mode) == 0 {
+			spec.PrioritizerPolicy.Mode = clusterv1beta1.PrioritizerPolicyModeAdditive
 		}
 		for _, p := range o.Prioritizers {
 			config, err := parsePrioritizer(p)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			desiredPlacement.Spec.PrioritizerPolicy.Configurations = append(desiredPlacement.Spec.PrioritizerPolicy.Configurations, *config)
+			spec.PrioritizerPolicy.Configurations = append(spec.PrioritizerPolicy.Configurations, *config)
 		}
 	}
 
-	return o.applyPlacement(clusterClient, desiredPlacement)
+	for _, t := range o.Tolerations {
+		toleration, err := parseToleration(t)
+		if err != nil {
+			return nil, err
+		}
+		spec.Tolerations = append(spec.Tolerations, *toleration)
+	}
+
+	for _, c := range o.SpreadConstraints {
+		term, err := parseSpreadConstraint(c)
+		if err != nil {
+			return nil, err
+		}
+		spec.SpreadPolicy.SpreadConstraints = append(spec.SpreadPolicy.SpreadConstraints, *term)
+	}
+
+	for _, g := range o.DecisionGroups {
+		group, err := parseDecisionGroup(g)
+		if err != nil {
+			return nil, err
+		}
+		spec.DecisionStrategy.GroupStrategy.DecisionGroups = append(spec.DecisionStrategy.GroupStrategy.DecisionGroups, *group)
+	}
+
+	if len(o.ClustersPerDecisionGroup) > 0 {
+		clustersPerDecisionGroup := intstr.Parse(o.ClustersPerDecisionGroup)
+		// the validation of the spec takes 0 as not set, so the flag is validated here
+		if err := placementhelpers.ValidateClustersPerDecisionGroup(clustersPerDecisionGroup); err != nil {
+			return nil, fmt.Errorf("invalid placement %s: %v", o.Placement, err)
+		}
+		spec.DecisionStrategy.GroupStrategy.ClustersPerDecisionGroup = clustersPerDecisionGroup
+	}
+
+	if err := placementhelpers.ValidateSpec(spec); err != nil {
+		return nil, fmt.Errorf("invalid placement %s: %v", o.Placement, err)
+	}
+	return desiredPlacement, nil
+}
+
+// readSpecFile reads the placement spec from the file, which contains either a placement or its spec.
+func readSpecFile(path string) (*clusterv1beta1.PlacementSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %v", path, err)
+	}
+
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(jsonData, typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %v", path, err)
+	}
+	var target interface{} = &clusterv1beta1.PlacementSpec{}
+	if typeMeta.Kind == "Placement" {
+		target = &clusterv1beta1.Placement{}
+	}
+
+	// unknown fields are rejected, so a typo in the file is not ignored silently
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %v", path, err)
+	}
+	if placement, ok := target.(*clusterv1beta1.Placement); ok {
+		return &placement.Spec, nil
+	}
+	return target.(*clusterv1beta1.PlacementSpec), nil
+}
+
+// parseClaimSelector parses a selector of the cluster claims in the format of a label selector.
+func parseClaimSelector(s string) (*clusterv1beta1.ClusterClaimSelector, error) {
+	selector, err := metav1.ParseToLabelSelector(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse claim selector %s: %v", s, err)
+	}
+	claimSelector := &clusterv1beta1.ClusterClaimSelector{
+		MatchExpressions: selector.MatchExpressions,
+	}
+	// the claim selector supports the match expressions only
+	for key, value := range selector.MatchLabels {
+		claimSelector.MatchExpressions = append(claimSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      key,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{value},
+		})
+	}
+	sort.Slice(claimSelector.MatchExpressions, func(i, j int) bool {
+		return claimSelector.MatchExpressions[i].Key < claimSelector.MatchExpressions[j].Key
+	})
+	return claimSelector, nil
+}
+
+// parseToleration parses a toleration in the format of {key}[={value}][:{effect}[:{tolerationSeconds}]].
+// The operator is Equal if the value is set, otherwise Exists.
+func parseToleration(s string) (*clusterv1beta1.Toleration, error) {
+	ps := strings.Split(s, ":")
+	if len(ps) > 3 || len(ps[0]) == 0 {
+		return nil, fmt.Errorf("toleration %s format is not correct, should be {key}[={value}][:{effect}[:{tolerationSeconds}]]", s)
+	}
+
+	toleration := &clusterv1beta1.Toleration{Operator: clusterv1beta1.TolerationOpExists}
+	toleration.Key = ps[0]
+	if key, value, found := strings.Cut(ps[0], "="); found {
+		toleration.Key = key
+		toleration.Value = value
+		toleration.Operator = clusterv1beta1.TolerationOpEqual
+	}
+	if len(ps) > 1 {
+		toleration.Effect = clusterv1.TaintEffect(ps[1])
+	}
+	if len(ps) > 2 {
+		seconds, err := strconv.ParseInt(ps[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("toleration seconds in %s is not correct: %v", s, err)
+		}
+		toleration.TolerationSeconds = ptr.To[int64](seconds)
+	}
+	return toleration, nil
+}
+
+// parseSpreadConstraint parses a spread constraint in the format of
+// {Label|Claim}:{topologyKey}[:{maxSkew}[:{DoNotSchedule|ScheduleAnyway}]].
+func parseSpreadConstraint(s string) (*clusterv1beta1.SpreadConstraintsTerm, error) {
+	ps := strings.Split(s, ":")
+	if len(ps) < 2 || len(ps) > 4 {
+		return nil, fmt.Errorf("spread constraint %s format is not correct, should be {Label|Claim}:{topologyKey}[:{maxSkew}[:{whenUnsatisfiable}]]", s)
+	}
+
+	term := &clusterv1beta1.SpreadConstraintsTerm{
+		TopologyKeyType:   clusterv1beta1.TopologyKeyType(ps[0]),
+		TopologyKey:       ps[1],
+		MaxSkew:           1,
+		WhenUnsatisfiable: clusterv1beta1.ScheduleAnyway,
+	}
+	if len(ps) > 2 {
+		maxSkew, err := strconv.ParseInt(ps[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("max skew in spread constraint %s is not correct: %v", s, err)
+		}
+		term.MaxSkew = int32(maxSkew)
+	}
+	if len(ps) > 3 {
+		term.WhenUnsatisfiable = clusterv1beta1.UnsatisfiableMaxSkewAction(ps[3])
+	}
+	return term, nil
+}
+
+// parseDecisionGroup parses a decision group in the format of {groupName}:{labelSelector}.
+func parseDecisionGroup(s string) (*clusterv1beta1.DecisionGroup, error) {
+	name, selector, found := strings.Cut(s, ":")
+	if !found {
+		return nil, fmt.Errorf("decision group %s format is not correct, should be {groupName}:{labelSelector}", s)
+	}
+	labelSelector, err := metav1.ParseToLabelSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse selector of decision group %s: %v", s, err)
+	}
+	return &clusterv1beta1.DecisionGroup{
+		GroupName: name,
+		ClusterSelector: clusterv1beta1.GroupClusterSelector{
+			LabelSelector: *labelSelector,
+		},
+	}, nil
+}
+
+// simulate prints the clusters the placement would select and the scores of each prioritizer.
+func (o *Options) simulate(clusterClient clusterclientset.Interface, placement *clusterv1beta1.Placement) error {
+	input, err := placementhelpers.LoadInput(context.TODO(), clusterClient, placement)
+	if err != nil {
+		return err
+	}
+	result := placementhelpers.Simulate(input)
+
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(o.Streams.ErrOut, "Warning: %s\n", warning)
+	}
+	selected := result.SelectedClusters()
+	fmt.Fprintf(o.Streams.Out, "Placement '%s' in '%s' namespace would select %d cluster(s): %s\n",
+		placement.Name, placement.Namespace, len(selected), strings.Join(selected, ","))
+
+	w := tabwriter.NewWriter(o.Streams.Out, 4, 8, 2, ' ', 0)
+	header := []string{"CLUSTER", "SELECTED", "SCORE"}
+	for _, p := range result.Prioritizers {
+		header = append(header, fmt.Sprintf("%s(%d)", strings.ToUpper(p.Name), p.Weight))
+	}
+	header = append(header, "REASON")
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, c := range result.Clusters {
+		row := []string{c.Name, strconv.FormatBool(c.Selected), "-"}
+		if c.Feasible {
+			row[2] = strconv.FormatInt(c.Score, 10)
+		}
+		for _, p := range result.Prioritizers {
+			score, ok := c.Scores[p.Name]
+			if !ok {
+				row = append(row, "-")
+				continue
+			}
+			row = append(row, strconv.FormatInt(score, 10))
+		}
+		row = append(row, c.Reason)
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
 }
 
 func parsePrioritizer(s string) (*clusterv1beta1.PrioritizerConfig, error) {
@@ -2,9 +2,13 @@
 package placement
 
 import (
+	"testing"
+
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/utils/ptr"
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
-	"testing"
 )
 
 func TestParsePrioritizer(t *testing.T) {
@@ -72,3 +76,153 @@ func TestParsePrioritizer(t *testing.T) {
 		})
 	}
 }
+
+func TestParseToleration(t *testing.T) {
+	cases := []struct {
+		name               string
+		toleration         string
+		expectErr          bool
+		expectedToleration *clusterv1beta1.Toleration
+	}{
+		{
+			name:      "empty string",
+			expectErr: true,
+		},
+		{
+			name:       "key only",
+			toleration: "maintenance",
+			expectedToleration: &clusterv1beta1.Toleration{
+				Key:      "maintenance",
+				Operator: clusterv1beta1.TolerationOpExists,
+			},
+		},
+		{
+			name:       "key and value with effect and seconds",
+			toleration: "gpu=true:NoSelect:300",
+			expectedToleration: &clusterv1beta1.Toleration{
+				Key:               "gpu",
+				Value:             "true",
+				Operator:          clusterv1beta1.TolerationOpEqual,
+				Effect:            "NoSelect",
+				TolerationSeconds: ptr.To[int64](300),
+			},
+		},
+		{
+			name:       "wrong seconds",
+			toleration: "gpu:NoSelect:abc",
+			expectErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := parseToleration(c.toleration)
+			if err != nil && !c.expectErr {
+				t.Errorf("should not have error, but got %v", err)
+			}
+			if err == nil && c.expectErr {
+				t.Errorf("should return err")
+			}
+			if !equality.Semantic.DeepEqual(actual, c.expectedToleration) {
+				t.Errorf("expected toleration not correct, actual %v", actual)
+			}
+		})
+	}
+}
+
+func TestBuildPlacement(t *testing.T) {
+	cases := []struct {
+		name      string
+		options   *Options
+		expectErr bool
+	}{
+		{
+			name: "claim, spread and decision groups",
+			options: &Options{
+				Placement:                "test",
+				ClaimSelectors:           []string{"region in (us-east-1,us-west-1)"},
+				SpreadConstraints:        []string{"Claim:region"},
+				DecisionGroups:           []string{"canary:env=canary"},
+				ClustersPerDecisionGroup: "25%",
+			},
+		},
+		{
+			name: "invalid spread constraint type",
+			options: &Options{
+				Placement:         "test",
+				SpreadConstraints: []string{"Zone:region"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid clusters per decision group",
+			options: &Options{
+				Placement:                "test",
+				ClustersPerDecisionGroup: "200%",
+			},
+			expectErr: true,
+		},
+		{
+			name: "zero clusters per decision group",
+			options: &Options{
+				Placement:                "test",
+				ClustersPerDecisionGroup: "0",
+			},
+			expectErr: true,
+		},
+		{
+			name: "duplicated decision groups",
+			options: &Options{
+				Placement:      "test",
+				DecisionGroups: []string{"canary:env=canary", "canary:env=prod"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.options.buildPlacement()
+			if err != nil && !c.expectErr {
+				t.Errorf("should not have error, but got %v", err)
+			}
+			if err == nil && c.expectErr {
+				t.Errorf("should return err")
+			}
+		})
+	}
+}
+
+func TestClaimSelectorsFlag(t *testing.T) {
+	o := newOptions(nil, genericiooptions.NewTestIOStreamsDiscard())
+	cmd := newCmd(o)
+	if err := cmd.ParseFlags([]string{
+		"--claim-selectors", "region in (us-east-1,us-west-1)",
+		"--claim-selectors", "region=us-east-1,platform=aws",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	o.Placement = "test"
+
+	placement, err := o.buildPlacement()
+	if err != nil {
+		t.Fatalf("should not have error, but got %v", err)
+	}
+	expected := []clusterv1beta1.ClusterClaimSelector{
+		{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "region", Operator: metav1.LabelSelectorOpIn, Values: []string{"us-east-1", "us-west-1"}},
+		}},
+		{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "platform", Operator: metav1.LabelSelectorOpIn, Values: []string{"aws"}},
+			{Key: "region", Operator: metav1.LabelSelectorOpIn, Values: []string{"us-east-1"}},
+		}},
+	}
+	if len(placement.Spec.Predicates) != len(expected) {
+		t.Fatalf("expected %d predicates, got %v", len(expected), placement.Spec.Predicates)
+	}
+	for i, predicate := range placement.Spec.Predicates {
+		if !equality.Semantic.DeepEqual(predicate.RequiredClusterSelector.ClaimSelector, expected[i]) {
+			t.Errorf("expected claim selector %v, got %v", expected[i], predicate.RequiredClusterSelector.ClaimSelector)
+		}
+	}
+}
@@ -26,9 +26,33 @@ type Options struct {
 	// Builtin:{Type}:{Weight} or Addon:{Type}:{ScoreName}:{Weight}
 	Prioritizers []string
 
+	// ClaimSelectors, CelSelectors are the selectors of the cluster claims and the CEL expressions, each of
+	// them is added as a predicate of the placement
+	ClaimSelectors []string
+	CelSelectors   []string
+
+	// Tolerations is a string array to define the tolerations in the format of
+	// {key}[={value}][:{effect}[:{tolerationSeconds}]]
+	Tolerations []string
+
+	// SpreadConstraints is a string array to define the spread policy in the format of
+	// {Label|Claim}:{topologyKey}[:{maxSkew}[:{whenUnsatisfiable}]]
+	SpreadConstraints []string
+
+	// DecisionGroups is a string array to define the decision groups in the format of {groupName}:{labelSelector}
+	DecisionGroups []string
+
+	ClustersPerDecisionGroup string
+
+	// SpecFile is the path of a file with the placement or its spec, the flags are added to the spec
+	SpecFile string
+
 	NumOfClusters int32
 
 	Overwrite bool
+
+	// Simulate evaluates the placement against the current clusters without creating it
+	Simulate bool
 }
 
 func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
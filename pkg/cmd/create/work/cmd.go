@@ -6,6 +6,7 @@ import (
 
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
 	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
 
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
@@ -27,6 +28,46 @@ var example = `
 # For example, if placement1 update decision to cluster2 and cluster3, 
 # then the manifestwork will be deleted from cluster1 and created on cluster3.
 %[1]s create work work-example -f xxx.yaml --placement default/placement1 --overwrite
+
+# Create manifestwork from a kustomize directory.
+%[1]s create work work-example -k ./overlays/prod --clusters cluster1
+
+# Create manifestwork from a local helm chart, the values files are rendered per cluster as Go templates
+# with {{ .ClusterName }}, {{ index .Labels "<key>" }}, {{ index .Annotations "<key>" }} and {{ index .Claims "<name>" }}.
+%[1]s create work work-example --helm-chart ./mychart --helm-values values.yaml --placement default/placement1
+
+# Create manifestwork keeping the namespace on the cluster when the work is deleted, applying the resources with
+# server side apply, and reporting the status of the deployment.
+%[1]s create work work-example -f xxx.yaml --clusters cluster1 --orphan namespaces/app --update-strategy ServerSideApply \
+  --feedback deployments.apps/app/web=WellKnownStatus --feedback deployments.apps/app/web=ready:.status.readyReplicas
+
+# Create manifestwork from manifest files rendered per cluster as Go templates with {{ .ClusterName }},
+# {{ index .Labels "<key>" }}, {{ index .Annotations "<key>" }} and {{ index .Claims "<name>" }}.
+%[1]s create work work-example -f xxx.yaml --template --placement default/placement1
+
+# Create manifestwork with a JSON patch of the deployment rendered per cluster, and show the rendered works
+# without creating them.
+%[1]s create work work-example -f xxx.yaml --patch deployments.apps/app/web=patch.yaml --placement default/placement1 --dry-run
+
+# Create manifestwork from the resources labeled app=web in the namespace app of the cluster of a kubeconfig context,
+# without the status and the fields populated by the server.
+%[1]s create work work-example --from-cluster-context kind-dev --namespace app --selector app=web --clusters cluster1
+
+# Create manifestworkreplicaset from the deployments and services in a namespace, including the namespace.
+%[1]s create work work-example --from-cluster-context kind-dev --namespace app --resources deployments,services \
+  --with-namespace --placement default/placement1 --replicaset
+
+# Create manifestworks from a large directory of manifests, split into the works work-example-0 to work-example-N
+# of at most 500KB each, with the CustomResourceDefinitions in the first works.
+%[1]s create work work-example -f ./bundle --clusters cluster1 --max-work-size 512000
+
+# Create manifestwork with the delete option and manifest configs in a spec file.
+%[1]s create work work-example -f xxx.yaml --clusters cluster1 --spec-file work-spec.yaml
+
+# Create manifestworkreplicaset rolled out to 2 clusters at a time, proceeding when the clusters are available
+# for 5 minutes, and stopping when more than 1 cluster fails or is not available in 30 minutes.
+%[1]s create work work-example -f xxx.yaml --placement default/placement1 --replicaset --rollout progressive \
+  --max-concurrency 2 --min-success-time 5m --progress-deadline 30m --max-failures 1
 `
 
 // NewCmd...
@@ -36,7 +77,7 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 	cmd := &cobra.Command{
 		Use:          "work",
 		Short:        "create a work using resource-to-apply yaml file",
-		Long:         "create a work using a file containing common kubernetes resource manifests, or a director containing a set of manifest files.",
+		Long:         "create a work using a file containing common kubernetes resource manifests, a director containing a set of manifest files, a kustomize directory, a helm chart or the resources exported from a cluster.",
 		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
 		SilenceUsage: true,
 		PreRunE: func(c *cobra.Command, args []string) error {
@@ -63,6 +104,48 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 	cmd.Flags().StringVar(&o.Placement, "placement", "", "Specify an existing placement with format <namespace>/<name>")
 	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", false, "Overwrite the existing work if it exists already")
 	cmd.Flags().BoolVarP(&o.UseReplicaSet, "replicaset", "r", false, "Create Manifestwork for the associated placement's cluster using ManifestWorkReplicaSet")
+	cmd.Flags().BoolVar(&o.Template, "template", false,
+		"Render the manifest files as Go templates with the name, labels, annotations and claims of each cluster")
+	cmd.Flags().StringArrayVar(&o.Patches, "patch", []string{},
+		"The JSON patch of a resource in the format of {resource}[.{group}]/[{namespace}/]{name}={file}, "+
+			"the file is rendered as a Go template with the values of each cluster")
+	cmd.Flags().StringVar(&o.HelmChart, "helm-chart", "", "The local directory or archive of a helm chart to render into the manifests of the work")
+	cmd.Flags().StringArrayVar(&o.HelmValues, "helm-values", []string{},
+		"The values files of the helm chart, rendered as Go templates with the name, labels, annotations and claims of each cluster")
+	cmd.Flags().StringVar(&o.HelmNamespace, "helm-namespace", "default", "The release namespace to render the helm chart")
+	cmd.Flags().StringVar(&o.FromClusterContext, "from-cluster-context", "",
+		"The kubeconfig context of the cluster to export the resources of the work from")
+	cmd.Flags().StringVarP(&o.FromNamespace, "namespace", "n", "",
+		"The namespace of the resources exported from the cluster, the namespace of the context if it is not specified")
+	cmd.Flags().StringVarP(&o.FromSelector, "selector", "l", "", "The label selector of the resources exported from the cluster")
+	cmd.Flags().StringSliceVar(&o.FromResources, "resources", defaultExportResources,
+		"The resource types exported from the cluster")
+	cmd.Flags().BoolVar(&o.WithNamespace, "with-namespace", false, "Add the namespace of the resources exported from the cluster to the work")
+	cmd.Flags().StringVar(&o.SpecFile, "spec-file", "",
+		"The file of a ManifestWork or its spec with the delete option and manifest configs, the other flags take precedence")
+	cmd.Flags().StringVar(&o.DeleteOption, "delete-option", "",
+		"The propagation policy when the work is deleted: Foreground, Orphan or SelectivelyOrphan")
+	cmd.Flags().StringArrayVar(&o.Orphans, "orphan", []string{},
+		"The resource orphaned when the work is deleted with SelectivelyOrphan, in the format of {resource}[.{group}]/[{namespace}/]{name}")
+	cmd.Flags().StringArrayVar(&o.UpdateStrategies, "update-strategy", []string{},
+		"The update strategy Update, CreateOnly, ServerSideApply or ReadOnly of all the resources, "+
+			"or of a resource in the format of {resource}[.{group}]/[{namespace}/]{name}={strategy}")
+	cmd.Flags().StringArrayVar(&o.Feedbacks, "feedback", []string{},
+		"The status feedback of a resource in the format of {resource}[.{group}]/[{namespace}/]{name}=WellKnownStatus "+
+			"or {resource}[.{group}]/[{namespace}/]{name}={name}:{jsonpath}")
+	cmd.Flags().StringVar(&o.Rollout.Type, "rollout", workhelpers.RolloutAll,
+		"The rollout strategy of the manifestworkreplicaset: all, progressive or progressive-per-group")
+	cmd.Flags().StringVar(&o.Rollout.MaxConcurrency, "max-concurrency", "",
+		"The number or percentage of clusters to roll out concurrently with the rollout progressive")
+	cmd.Flags().StringVar(&o.Rollout.MinSuccessTime, "min-success-time", "",
+		"The time to wait after the clusters are available before proceeding with the progressive rollouts, e.g. 5m")
+	cmd.Flags().StringVar(&o.Rollout.ProgressDeadline, "progress-deadline", "",
+		"The time to wait for a cluster to be available before it is counted as failed, e.g. 30m, or None to wait forever")
+	cmd.Flags().StringVar(&o.Rollout.MaxFailures, "max-failures", "",
+		"The number or percentage of clusters that can fail before the rollout stops")
+	cmd.Flags().IntVar(&o.MaxWorkSize, "max-work-size", workhelpers.DefaultMaxWorkSize,
+		"The max size in bytes of the manifests of a work, larger works are split into the works {name}-0 to {name}-N "+
+			"with the CustomResourceDefinitions in the first works")
 	o.FileNameFlags.AddFlags(cmd.Flags())
 
 	return cmd
@@ -2,16 +2,24 @@
 package work
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/resource"
 
 	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
@@ -19,6 +27,8 @@ import (
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	workapiv1 "open-cluster-management.io/api/work/v1"
 	workapiv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+	"open-cluster-management.io/clusteradm/pkg/helpers/helm"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
 	clustersdkv1beta1 "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
 )
 
@@ -33,6 +43,14 @@ func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
 
 	o.Workname = args[0]
 
+	for _, patch := range o.Patches {
+		overlay, err := workhelpers.ParseOverlay(patch)
+		if err != nil {
+			return err
+		}
+		o.overlays = append(o.overlays, overlay)
+	}
+
 	return nil
 }
 
@@ -58,8 +76,57 @@ func (o *Options) validate() error {
 	if o.UseReplicaSet && len(o.Placement) == 0 {
 		return fmt.Errorf("--placement must be specified when enable --replicaset")
 	}
-	if len(*o.FileNameFlags.Filenames) == 0 {
-		return fmt.Errorf("manifest files must be specified")
+	if !o.UseReplicaSet && o.Rollout != (workhelpers.RolloutOptions{Type: workhelpers.RolloutAll}) {
+		return fmt.Errorf("the rollout strategy can only be specified with --replicaset")
+	}
+	if _, err := o.Rollout.RolloutStrategy(); err != nil {
+		return err
+	}
+
+	sources := 0
+	for _, source := range []bool{
+		len(*o.FileNameFlags.Filenames) > 0, len(*o.FileNameFlags.Kustomize) > 0, len(o.HelmChart) > 0, len(o.FromClusterContext) > 0,
+	} {
+		if source {
+			sources++
+		}
+	}
+	if sources == 0 {
+		return fmt.Errorf("manifest files (-f), a kustomize directory (-k), a helm chart (--helm-chart) " +
+			"or a cluster context (--from-cluster-context) must be specified")
+	}
+	if sources > 1 {
+		return fmt.Errorf("only one of -f, -k, --helm-chart and --from-cluster-context can be specified")
+	}
+	if len(o.FromClusterContext) == 0 && (len(o.FromNamespace) > 0 || len(o.FromSelector) > 0 || o.WithNamespace) {
+		return fmt.Errorf("--namespace, --selector and --with-namespace can only be specified with --from-cluster-context")
+	}
+	if len(o.FromClusterContext) > 0 {
+		if len(o.FromResources) == 0 {
+			return fmt.Errorf("--resources must not be empty")
+		}
+		if _, err := labels.Parse(o.FromSelector); err != nil {
+			return fmt.Errorf("invalid selector %s: %v", o.FromSelector, err)
+		}
+	}
+	if len(o.HelmValues) > 0 && len(o.HelmChart) == 0 {
+		return fmt.Errorf("--helm-values can only be specified with --helm-chart")
+	}
+	if o.Template {
+		if len(*o.FileNameFlags.Filenames) == 0 {
+			return fmt.Errorf("--template can only be specified with manifest files (-f)")
+		}
+		for _, file := range *o.FileNameFlags.Filenames {
+			if file == "-" || strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://") {
+				return fmt.Errorf("--template can only render local manifest files, %s is not supported", file)
+			}
+		}
+	}
+	if o.MaxWorkSize <= 0 {
+		return fmt.Errorf("--max-work-size must be positive")
+	}
+	if _, err := o.buildSpec(nil); err != nil {
+		return err
 	}
 
 	return nil
@@ -79,9 +146,13 @@ func (o *Options) run() error {
 		return err
 	}
 
-	manifests, err := o.readManifests()
-	if err != nil {
-		return err
+	// the manifests rendered per cluster are read when applying the work to each cluster
+	var manifests []workapiv1.Manifest
+	if !o.perClusterManifests() {
+		manifests, err = o.readManifests(nil)
+		if err != nil {
+			return err
+		}
 	}
 
 	addedClusters, deletedClusters, err := o.getClusters(workClient, clusterClient)
@@ -94,7 +165,7 @@ func (o *Options) run() error {
 			return err
 		}
 	} else {
-		if err := o.applyWork(workClient, manifests, addedClusters, deletedClusters); err != nil {
+		if err := o.applyWork(workClient, clusterClient, manifests, addedClusters, deletedClusters); err != nil {
 			return err
 		}
 	}
@@ -102,15 +173,247 @@ func (o *Options) run() error {
 	return nil
 }
 
-func (o *Options) readManifests() ([]workapiv1.Manifest, error) {
-	opt := o.FileNameFlags.ToOptions()
+// perClusterManifests returns true if the manifests are rendered for each cluster, with the manifest files, the
+// helm values or the patches templated by the cluster. A ManifestWorkReplicaSet has the same manifests on all
+// the clusters.
+func (o *Options) perClusterManifests() bool {
+	return (len(o.HelmValues) > 0 || o.Template || len(o.overlays) > 0) && !o.UseReplicaSet
+}
+
+// readManifests reads the manifests from the files, the kustomize directory, the helm chart or the cluster, and applies
+// the patches. The templates are rendered with the values of the cluster, which is nil if the manifests are
+// not per cluster.
+func (o *Options) readManifests(values *workhelpers.ClusterValues) ([]workapiv1.Manifest, error) {
+	var manifests []workapiv1.Manifest
+	var err error
+	switch {
+	case len(o.HelmChart) > 0:
+		manifests, err = o.renderChart(values)
+	case len(o.FromClusterContext) > 0:
+		manifests, err = o.exportManifests()
+	case o.Template:
+		manifests, err = o.renderFiles(values)
+	default:
+		opt := o.FileNameFlags.ToOptions()
+		if len(opt.Kustomize) > 0 {
+			// the kustomize directory is not read recursively
+			opt.Recursive = false
+		}
+		builder := resource.NewLocalBuilder().
+			Unstructured().
+			FilenameParam(false, &opt).
+			Flatten().
+			ContinueOnError()
+		manifests, err = manifestsOf(builder.Do())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return workhelpers.ApplyOverlays(manifests, o.overlays, values)
+}
+
+// renderFiles renders the manifest files as Go templates with the values of the cluster.
+func (o *Options) renderFiles(values *workhelpers.ClusterValues) ([]workapiv1.Manifest, error) {
+	files, err := manifestFiles(*o.FileNameFlags.Filenames, *o.FileNameFlags.Recursive)
+	if err != nil {
+		return nil, err
+	}
+	builder := resource.NewLocalBuilder().Unstructured()
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := workhelpers.RenderTemplate(file, content, values)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.Stream(bytes.NewReader(rendered), file)
+	}
+	return manifestsOf(builder.Flatten().ContinueOnError().Do())
+}
+
+// manifestFiles returns the files with the extensions of the manifests in the paths, the directories are
+// walked recursively if recursive is true.
+func manifestFiles(paths []string, recursive bool) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if file != path && !recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if slices.Contains(resource.FileExtensions, filepath.Ext(file)) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// renderChart renders the helm chart with the values files templated by the values of the cluster.
+func (o *Options) renderChart(values *workhelpers.ClusterValues) ([]workapiv1.Manifest, error) {
+	var valuesFiles [][]byte
+	for _, file := range o.HelmValues {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := workhelpers.RenderTemplate(file, content, values)
+		if err != nil {
+			return nil, err
+		}
+		valuesFiles = append(valuesFiles, rendered)
+	}
+
+	rendered, err := helm.RenderChart(o.HelmChart, o.Workname, o.HelmNamespace, valuesFiles...)
+	if err != nil {
+		return nil, err
+	}
 	builder := resource.NewLocalBuilder().
 		Unstructured().
-		FilenameParam(false, &opt).
+		Stream(strings.NewReader(rendered), o.HelmChart).
 		Flatten().
 		ContinueOnError()
-	result := builder.Do()
+	return manifestsOf(builder.Do())
+}
+
+// exportManifests reads the resources from the cluster of the context, and strips the status and the fields
+// populated by the server. The resources owned by controllers are skipped, as they are recreated by their owners.
+func (o *Options) exportManifests() ([]workapiv1.Manifest, error) {
+	kubeconfig := o.ClusteradmFlags.KubectlFactory.ToRawKubeConfigLoader().ConfigAccess().GetExplicitFile()
+	configFlags := genericclioptions.NewConfigFlags(false)
+	configFlags.KubeConfig = &kubeconfig
+	configFlags.Context = &o.FromClusterContext
+
+	namespace := o.FromNamespace
+	if len(namespace) == 0 {
+		var err error
+		namespace, _, err = configFlags.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := resource.NewBuilder(configFlags).
+		Unstructured().
+		NamespaceParam(namespace).
+		LabelSelectorParam(o.FromSelector).
+		ResourceTypeOrNameArgs(true, strings.Join(o.FromResources, ",")).
+		Flatten().
+		ContinueOnError().
+		Do()
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	items, err := result.Infos()
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []workapiv1.Manifest
+	if o.WithNamespace {
+		ns := &unstructured.Unstructured{}
+		ns.SetAPIVersion("v1")
+		ns.SetKind("Namespace")
+		ns.SetName(namespace)
+		manifests = append(manifests, workapiv1.Manifest{RawExtension: runtime.RawExtension{Object: ns}})
+	}
+	for _, item := range items {
+		obj, ok := item.Object.(*unstructured.Unstructured)
+		if !ok || !workhelpers.Exportable(obj) {
+			continue
+		}
+		manifests = append(manifests, workapiv1.Manifest{RawExtension: runtime.RawExtension{Object: workhelpers.ExportObject(obj)}})
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no resources of %s found in the namespace %s of the context %s",
+			strings.Join(o.FromResources, ","), namespace, o.FromClusterContext)
+	}
+	return manifests, nil
+}
+
+// clusterManifests returns the manifests rendered with the values of the cluster.
+func (o *Options) clusterManifests(clusterClient clusterclientset.Interface, clusterName string) ([]workapiv1.Manifest, error) {
+	cluster, err := clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return o.readManifests(workhelpers.NewClusterValues(cluster))
+}
+
+// buildSpec returns the spec of the work with the manifests, and the delete option and manifest configs
+// from the spec file and the flags. The flags take precedence over the spec file.
+func (o *Options) buildSpec(manifests []workapiv1.Manifest) (workapiv1.ManifestWorkSpec, error) {
+	spec := workapiv1.ManifestWorkSpec{}
+	if len(o.SpecFile) > 0 {
+		fileSpec, err := workhelpers.ReadSpecFile(o.SpecFile)
+		if err != nil {
+			return spec, err
+		}
+		spec = *fileSpec
+	}
+	spec.Workload.Manifests = manifests
 
+	deleteOption, err := workhelpers.ParseDeleteOption(o.DeleteOption, o.Orphans)
+	if err != nil {
+		return spec, err
+	}
+	if deleteOption != nil {
+		spec.DeleteOption = deleteOption
+	}
+
+	for _, s := range o.UpdateStrategies {
+		id, strategy, err := workhelpers.ParseUpdateStrategy(s)
+		if err != nil {
+			return spec, err
+		}
+		var ids []workapiv1.ResourceIdentifier
+		if id != nil {
+			ids = append(ids, *id)
+		} else {
+			// the strategy without a resource applies to all the manifests
+			for _, manifest := range manifests {
+				manifestID, err := workhelpers.ResourceIdentifierOf(manifest)
+				if err != nil {
+					return spec, err
+				}
+				ids = append(ids, manifestID)
+			}
+		}
+		for _, id := range ids {
+			workhelpers.ManifestConfig(&spec.ManifestConfigs, id).UpdateStrategy = strategy
+		}
+	}
+
+	for _, s := range o.Feedbacks {
+		id, rule, err := workhelpers.ParseFeedback(s)
+		if err != nil {
+			return spec, err
+		}
+		workhelpers.AddFeedbackRule(workhelpers.ManifestConfig(&spec.ManifestConfigs, id), rule)
+	}
+	return spec, nil
+}
+
+func manifestsOf(result *resource.Result) ([]workapiv1.Manifest, error) {
 	if err := result.Err(); err != nil {
 		return nil, err
 	}
@@ -151,7 +454,7 @@ func (o *Options) getWorkDepolyClusters(workClient workclientset.Interface) (set
 
 	depolyClusters := sets.New[string]()
 	for _, work := range works.Items {
-		if work.Name == o.Workname {
+		if work.Name == o.Workname || work.Labels[workhelpers.ShardOfLabel] == o.Workname {
 			depolyClusters.Insert(work.Namespace)
 		}
 	}
@@ -190,106 +493,366 @@ func (o *Options) applyWorkSet(workClient workclientset.Interface, clusterClient
 		return err
 	}
 
-	workSet, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(placement.Namespace).Get(context.TODO(), o.Workname, metav1.GetOptions{})
+	spec, err := o.buildSpec(manifests)
+	if err != nil {
+		return err
+	}
+	rolloutStrategy, err := o.Rollout.RolloutStrategy()
+	if err != nil {
+		return err
+	}
+	placementRefs := []workapiv1alpha1.LocalPlacementReference{
+		{Name: placement.Name, RolloutStrategy: rolloutStrategy},
+	}
+
+	// without overwrite, the existing manifestworkreplicaset or its shards are kept
+	if !o.Overwrite && !o.ClusteradmFlags.DryRun {
+		existing, err := o.existingWorkSets(workClient, placement.Namespace)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			_, err = fmt.Fprintf(o.Streams.Out, "manifestworkreplicaset %s in namespace %s already exists\n", o.Workname, placement.Namespace)
+			return err
+		}
+	}
 
-	switch {
-	case errors.IsNotFound(err):
-		workSet = &workapiv1alpha1.ManifestWorkReplicaSet{
+	shards, err := o.shards(spec)
+	if err != nil {
+		return err
+	}
+	for _, shard := range shards {
+		spec.Workload.Manifests = shard.Manifests
+		workSet := &workapiv1alpha1.ManifestWorkReplicaSet{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      o.Workname,
+				Name:      shard.Name,
 				Namespace: placement.Namespace,
+				Labels:    o.shardLabels(shards),
 			},
 			Spec: workapiv1alpha1.ManifestWorkReplicaSetSpec{
-				ManifestWorkTemplate: workapiv1.ManifestWorkSpec{
-					Workload: workapiv1.ManifestsTemplate{
-						Manifests: manifests,
-					},
-				},
-				PlacementRefs: []workapiv1alpha1.LocalPlacementReference{
-					{Name: placement.Name},
-				},
+				ManifestWorkTemplate: spec,
+				PlacementRefs:        placementRefs,
 			},
 		}
-		if _, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(placement.Namespace).Create(context.TODO(), workSet, metav1.CreateOptions{}); err != nil {
+		if err := o.applyWorkSetShard(workClient, workSet); err != nil {
 			return err
 		}
-		_, err = fmt.Fprintf(o.Streams.Out, "create manifestworkreplicaset %s in namespace %s\n", o.Workname, placement.Namespace)
+	}
+	if !o.Overwrite {
+		return nil
+	}
+
+	// the shards of the previous version not in the new version are deleted
+	existing, err := o.existingWorkSets(workClient, placement.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, name := range existing {
+		if slices.ContainsFunc(shards, func(shard workhelpers.Shard) bool { return shard.Name == name }) {
+			continue
+		}
+		if o.ClusteradmFlags.DryRun {
+			if _, err := fmt.Fprintf(o.Streams.Out, "# delete manifestworkreplicaset %s in namespace %s\n", name, placement.Namespace); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(placement.Namespace).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(o.Streams.Out, "delete manifestworkreplicaset %s in namespace %s\n", name, placement.Namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Options) applyWorkSetShard(workClient workclientset.Interface, required *workapiv1alpha1.ManifestWorkReplicaSet) error {
+	if o.ClusteradmFlags.DryRun {
+		required.TypeMeta = metav1.TypeMeta{APIVersion: workapiv1alpha1.GroupVersion.String(), Kind: "ManifestWorkReplicaSet"}
+		return o.printDryRun(required)
+	}
+
+	workSet, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(required.Namespace).Get(context.TODO(), required.Name, metav1.GetOptions{})
+
+	switch {
+	case errors.IsNotFound(err):
+		if _, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(required.Namespace).Create(context.TODO(), required, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(o.Streams.Out, "create manifestworkreplicaset %s in namespace %s\n", required.Name, required.Namespace)
 		return err
 	case err != nil:
 		return err
 	}
 
 	if !o.Overwrite {
-		_, err = fmt.Fprintf(o.Streams.Out, "manifestworkreplicaset %s in namespace %s already exists\n", o.Workname, placement.Namespace)
+		_, err = fmt.Fprintf(o.Streams.Out, "manifestworkreplicaset %s in namespace %s already exists\n", required.Name, required.Namespace)
 	} else {
-		workSet.Spec.ManifestWorkTemplate.Workload.Manifests = manifests
-		workSet.Spec.PlacementRefs = []workapiv1alpha1.LocalPlacementReference{{Name: placement.Name}}
-		if _, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(placement.Namespace).Update(context.TODO(), workSet, metav1.UpdateOptions{}); err != nil {
+		workSet.Labels = mergeLabels(workSet.Labels, required.Labels)
+		workSet.Spec.ManifestWorkTemplate.Workload = required.Spec.ManifestWorkTemplate.Workload
+		workSet.Spec.ManifestWorkTemplate.DeleteOption = required.Spec.ManifestWorkTemplate.DeleteOption
+		workSet.Spec.ManifestWorkTemplate.ManifestConfigs = required.Spec.ManifestWorkTemplate.ManifestConfigs
+		workSet.Spec.PlacementRefs = required.Spec.PlacementRefs
+		if _, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(required.Namespace).Update(context.TODO(), workSet, metav1.UpdateOptions{}); err != nil {
 			return err
 		}
-		_, err = fmt.Fprintf(o.Streams.Out, "update manifestworkreplicaset %s in namespace %s\n", o.Workname, placement.Namespace)
+		_, err = fmt.Fprintf(o.Streams.Out, "update manifestworkreplicaset %s in namespace %s\n", required.Name, required.Namespace)
 	}
 
 	return err
 }
 
-func (o *Options) applyWork(workClient workclientset.Interface, manifests []workapiv1.Manifest, addedClusters, deletedClusters sets.Set[string]) error {
+func (o *Options) applyWork(
+	workClient workclientset.Interface,
+	clusterClient clusterclientset.Interface,
+	manifests []workapiv1.Manifest,
+	addedClusters, deletedClusters sets.Set[string]) error {
 	for clusterName := range deletedClusters {
 		if o.Overwrite {
-			if err := workClient.WorkV1().ManifestWorks(clusterName).Delete(context.TODO(), o.Workname, metav1.DeleteOptions{}); err != nil {
-				return err
-			}
-			if _, err := fmt.Fprintf(o.Streams.Out, "delete work %s in cluster %s\n", o.Workname, clusterName); err != nil {
+			if err := o.deleteWorks(workClient, clusterName, nil); err != nil {
 				return err
 			}
 		}
 	}
 
 	for clusterName := range addedClusters {
-		work, err := workClient.WorkV1().ManifestWorks(clusterName).Get(context.TODO(), o.Workname, metav1.GetOptions{})
+		// without overwrite, the existing work or its shards are kept, so the shards of the new manifests
+		// are not created alongside the existing work with the same resources
+		if !o.Overwrite && !o.ClusteradmFlags.DryRun {
+			existing, err := o.existingWorks(workClient, clusterName)
+			if err != nil {
+				return err
+			}
+			if len(existing) > 0 {
+				if _, err := fmt.Fprintf(o.Streams.Out, "work %s in cluster %s already exists\n", o.Workname, clusterName); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		manifests := manifests
+		if o.perClusterManifests() {
+			var err error
+			manifests, err = o.clusterManifests(clusterClient, clusterName)
+			if err != nil {
+				return err
+			}
+		}
 
-		switch {
-		case errors.IsNotFound(err):
-			work = &workapiv1.ManifestWork{
+		spec, err := o.buildSpec(manifests)
+		if err != nil {
+			return err
+		}
+		shards, err := o.shards(spec)
+		if err != nil {
+			return err
+		}
+		names := sets.New[string]()
+		for _, shard := range shards {
+			spec.Workload.Manifests = shard.Manifests
+			work := &workapiv1.ManifestWork{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      o.Workname,
+					Name:      shard.Name,
 					Namespace: clusterName,
+					Labels:    o.workLabels(shards),
 				},
-				Spec: workapiv1.ManifestWorkSpec{
-					Workload: workapiv1.ManifestsTemplate{
-						Manifests: manifests,
-					},
-				},
+				Spec: spec,
 			}
-			if _, err := workClient.WorkV1().ManifestWorks(clusterName).Create(context.TODO(), work, metav1.CreateOptions{}); err != nil {
+			if err := o.applyClusterWork(workClient, work); err != nil {
 				return err
 			}
-			if _, err := fmt.Fprintf(o.Streams.Out, "create work %s in cluster %s\n", o.Workname, clusterName); err != nil {
+			names.Insert(shard.Name)
+		}
+
+		// the shards of the previous version not in the new version are deleted
+		if o.Overwrite {
+			if err := o.deleteWorks(workClient, clusterName, names); err != nil {
 				return err
 			}
-			continue
-		case err != nil:
+		}
+	}
+
+	return nil
+}
+
+func (o *Options) applyClusterWork(workClient workclientset.Interface, required *workapiv1.ManifestWork) error {
+	if o.ClusteradmFlags.DryRun {
+		required.TypeMeta = metav1.TypeMeta{APIVersion: workapiv1.GroupVersion.String(), Kind: "ManifestWork"}
+		return o.printDryRun(required)
+	}
+
+	work, err := workClient.WorkV1().ManifestWorks(required.Namespace).Get(context.TODO(), required.Name, metav1.GetOptions{})
+
+	switch {
+	case errors.IsNotFound(err):
+		if _, err := workClient.WorkV1().ManifestWorks(required.Namespace).Create(context.TODO(), required, metav1.CreateOptions{}); err != nil {
 			return err
 		}
+		_, err = fmt.Fprintf(o.Streams.Out, "create work %s in cluster %s\n", required.Name, required.Namespace)
+		return err
+	case err != nil:
+		return err
+	}
 
-		if !o.Overwrite {
-			if _, err := fmt.Fprintf(o.Streams.Out, "work %s in cluster %s already exists\n", o.Workname, clusterName); err != nil {
-				return err
-			}
-		} else {
-			work.Spec.Workload.Manifests = manifests
-			if _, err := workClient.WorkV1().ManifestWorks(clusterName).Update(context.TODO(), work, metav1.UpdateOptions{}); err != nil {
-				return err
+	if !o.Overwrite {
+		_, err = fmt.Fprintf(o.Streams.Out, "work %s in cluster %s already exists\n", required.Name, required.Namespace)
+		return err
+	}
+	work.Labels = mergeLabels(work.Labels, required.Labels)
+	work.Spec.Workload = required.Spec.Workload
+	work.Spec.DeleteOption = required.Spec.DeleteOption
+	work.Spec.ManifestConfigs = required.Spec.ManifestConfigs
+	if _, err := workClient.WorkV1().ManifestWorks(required.Namespace).Update(context.TODO(), work, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(o.Streams.Out, "update work %s in cluster %s\n", required.Name, required.Namespace)
+	return err
+}
+
+// shards splits the manifests of the spec into the works under the max work size. The manifest configs are in
+// every shard, so their size is not available to the manifests.
+func (o *Options) shards(spec workapiv1.ManifestWorkSpec) ([]workhelpers.Shard, error) {
+	configs := spec
+	configs.Workload.Manifests = nil
+	data, err := json.Marshal(configs)
+	if err != nil {
+		return nil, err
+	}
+	return workhelpers.SplitManifests(o.Workname, spec.Workload.Manifests, o.MaxWorkSize-len(data))
+}
+
+// shardLabels returns the labels of the works if the manifests are split into shards.
+func (o *Options) shardLabels(shards []workhelpers.Shard) map[string]string {
+	if len(shards) < 2 {
+		return nil
+	}
+	return map[string]string{workhelpers.ShardOfLabel: o.Workname}
+}
+
+// workLabels returns the labels of the works in the clusters, the works created by a placement are labeled
+// with it, so they are deleted from the clusters no longer selected by delete work --placement.
+func (o *Options) workLabels(shards []workhelpers.Shard) map[string]string {
+	labels := o.shardLabels(shards)
+	if len(o.Placement) > 0 {
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[workhelpers.PlacementLabel] = workhelpers.PlacementLabelValue(o.Placement)
+	}
+	return labels
+}
+
+// mergeLabels sets the shard and placement labels of the required labels on the existing labels, or removes
+// them if the work is not split anymore or not created by a placement.
+func mergeLabels(existing, required map[string]string) map[string]string {
+	for _, key := range []string{workhelpers.ShardOfLabel, workhelpers.PlacementLabel} {
+		delete(existing, key)
+		if value, ok := required[key]; ok {
+			if existing == nil {
+				existing = map[string]string{}
 			}
-			if _, err := fmt.Fprintf(o.Streams.Out, "update work %s in cluster %s\n", o.Workname, clusterName); err != nil {
+			existing[key] = value
+		}
+	}
+	return existing
+}
+
+// existingWorks returns the names of the work and its shards in the cluster.
+func (o *Options) existingWorks(workClient workclientset.Interface, clusterName string) ([]string, error) {
+	var names []string
+	_, err := workClient.WorkV1().ManifestWorks(clusterName).Get(context.TODO(), o.Workname, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		names = append(names, o.Workname)
+	case !errors.IsNotFound(err):
+		return nil, err
+	}
+	shards, err := workClient.WorkV1().ManifestWorks(clusterName).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: workhelpers.ShardSelector(o.Workname),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, shard := range shards.Items {
+		names = append(names, shard.Name)
+	}
+	return names, nil
+}
+
+// existingWorkSets returns the names of the manifestworkreplicaset and its shards in the namespace.
+func (o *Options) existingWorkSets(workClient workclientset.Interface, namespace string) ([]string, error) {
+	var names []string
+	_, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(namespace).Get(context.TODO(), o.Workname, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		names = append(names, o.Workname)
+	case !errors.IsNotFound(err):
+		return nil, err
+	}
+	shards, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: workhelpers.ShardSelector(o.Workname),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, shard := range shards.Items {
+		names = append(names, shard.Name)
+	}
+	return names, nil
+}
+
+// deleteWorks deletes the work and its shards in the cluster, except the works to keep.
+func (o *Options) deleteWorks(workClient workclientset.Interface, clusterName string, keep sets.Set[string]) error {
+	names, err := o.existingWorks(workClient, clusterName)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if keep.Has(name) {
+			continue
+		}
+		if o.ClusteradmFlags.DryRun {
+			// the rendered works are printed in yaml, so the deletion is a comment
+			if _, err := fmt.Fprintf(o.Streams.Out, "# delete work %s in cluster %s\n", name, clusterName); err != nil {
 				return err
 			}
+			continue
+		}
+		if err := workClient.WorkV1().ManifestWorks(clusterName).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(o.Streams.Out, "delete work %s in cluster %s\n", name, clusterName); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
+// printDryRun prints the work or the manifestworkreplicaset in yaml instead of applying it. The manifests
+// are encoded to raw, as the objects of a RawExtension are not printed.
+func (o *Options) printDryRun(obj runtime.Object) error {
+	var spec *workapiv1.ManifestWorkSpec
+	switch t := obj.(type) {
+	case *workapiv1.ManifestWork:
+		spec = &t.Spec
+	case *workapiv1alpha1.ManifestWorkReplicaSet:
+		spec = &t.Spec.ManifestWorkTemplate
+	}
+	for i, manifest := range spec.Workload.Manifests {
+		if manifest.Object == nil {
+			continue
+		}
+		raw, err := json.Marshal(manifest.Object)
+		if err != nil {
+			return err
+		}
+		spec.Workload.Manifests[i] = workapiv1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}
+	}
+	return o.dryRunPrinter.PrintObj(obj, o.Streams.Out)
+}
+
 type placementDecisionGetter struct {
 	clusterClient *clusterclientset.Clientset
 }
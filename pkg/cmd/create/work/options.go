@@ -4,9 +4,11 @@ package work
 import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/utils/ptr"
 
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
 )
 
 type Options struct {
@@ -26,6 +28,63 @@ type Options struct {
 	Overwrite bool
 
 	UseReplicaSet bool
+
+	// Template is to render the manifest files as Go templates per cluster
+	Template bool
+
+	// Patches are the JSON patches of the resources, rendered as Go templates per cluster
+	Patches []string
+
+	// overlays are the patches read from the files
+	overlays []workhelpers.Overlay
+
+	// HelmChart is the local directory or archive of a helm chart rendered into the manifests
+	HelmChart string
+
+	// HelmValues are the values files of the helm chart, rendered as Go templates per cluster
+	HelmValues []string
+
+	// HelmNamespace is the release namespace to render the helm chart
+	HelmNamespace string
+
+	// FromClusterContext is the kubeconfig context of the cluster the resources are exported from
+	FromClusterContext string
+
+	// FromNamespace is the namespace of the exported resources, the namespace of the context if it is empty
+	FromNamespace string
+
+	// FromSelector is the label selector of the exported resources
+	FromSelector string
+
+	// FromResources are the resource types exported from the cluster
+	FromResources []string
+
+	// WithNamespace is to add the namespace of the exported resources to the manifests
+	WithNamespace bool
+
+	// SpecFile is the path of a file with the delete option and manifest configs of the work
+	SpecFile string
+
+	// DeleteOption is the propagation policy to delete the resources when the work is deleted
+	DeleteOption string
+
+	// Orphans are the resources orphaned when the work is deleted with SelectivelyOrphan
+	Orphans []string
+
+	// UpdateStrategies are the update strategies of all or specific resources
+	UpdateStrategies []string
+
+	// Feedbacks are the status feedback rules of the resources
+	Feedbacks []string
+
+	// dryRunPrinter prints the rendered works with --dry-run
+	dryRunPrinter printers.YAMLPrinter
+
+	// MaxWorkSize is the max size in bytes of the manifests of a work, larger works are split into shards
+	MaxWorkSize int
+
+	// Rollout is the rollout strategy of the ManifestWorkReplicaSet on the clusters of the placement
+	Rollout workhelpers.RolloutOptions
 }
 
 func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
@@ -36,6 +95,14 @@ func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, st
 		FileNameFlags: genericclioptions.FileNameFlags{
 			Filenames: &[]string{},
 			Recursive: ptr.To[bool](true),
+			Kustomize: ptr.To[string](""),
 		},
 	}
 }
+
+// defaultExportResources are the resource types exported from a cluster by default. The secrets are not
+// exported unless they are specified, as the manifests of the works are not encrypted.
+var defaultExportResources = []string{
+	"deployments", "statefulsets", "daemonsets", "services", "configmaps", "serviceaccounts",
+	"persistentvolumeclaims", "ingresses",
+}
@@ -15,6 +15,19 @@ import (
 var example = `
 # Delete work in specified cluster
 %[1]s delete work work-example --cluster cluster1
+
+# Delete work in the clusters selected by a placement, and the clusters it was created in by the placement before
+# its decisions changed, waiting for at most 2 minutes
+%[1]s delete work work-example --placement default/placement1 --timeout 120
+
+# Delete the works with a label in all the clusters
+%[1]s delete work --selector app=web
+
+# Delete a manifestworkreplicaset, and the works created by it
+%[1]s delete work work-example --replicaset -n default
+
+# Delete work in specified cluster, removing its finalizers if the agent is not available
+%[1]s delete work work-example --cluster cluster1 --force
 `
 
 // NewCmd ...
@@ -22,8 +35,10 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 	o := newOptions(clusteradmFlags, streams)
 
 	cmd := &cobra.Command{
-		Use:          "work",
-		Short:        "delete work in specified cluster",
+		Use:   "work [NAME]",
+		Short: "delete work in specified cluster",
+		Long: "delete the work by name or label selector from the clusters, or the clusters selected by a placement, " +
+			"and wait until the work is deleted. The manifestworkreplicasets are deleted with --replicaset",
 		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
 		SilenceUsage: true,
 		PreRun: func(c *cobra.Command, args []string) {
@@ -46,6 +61,12 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 
 	o.ClusterOptions.AddFlags(cmd.Flags())
 	cmd.Flags().BoolVar(&o.Force, "force", false, "set force flag to enable force delete")
+	cmd.Flags().StringVarP(&o.Selector, "selector", "l", "", "The label selector of the works to delete, instead of the name")
+	cmd.Flags().StringVar(&o.Placement, "placement", "",
+		"Delete the work from the clusters selected by the placement in the format of <namespace>/<name>, "+
+			"and the clusters the work was created in with the placement before its decisions changed")
+	cmd.Flags().BoolVarP(&o.UseReplicaSet, "replicaset", "r", false, "Delete the manifestworkreplicasets instead of the works")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "default", "The namespace of the manifestworkreplicasets")
 
 	return cmd
 }
@@ -4,29 +4,36 @@ package work
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
 
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
 	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
-	"open-cluster-management.io/clusteradm/pkg/helpers"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
 )
 
 func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
-	if len(args) == 0 {
-		return fmt.Errorf("work name must be specified")
-	}
-
 	if len(args) > 1 {
 		return fmt.Errorf("only one work name can be specified")
 	}
-
-	o.Workname = args[0]
+	if len(args) == 1 {
+		o.Workname = args[0]
+	}
 
 	return nil
 }
@@ -41,6 +48,36 @@ func (o *Options) validate() error {
 		return err
 	}
 
+	if len(o.Workname) == 0 && len(o.Selector) == 0 {
+		return fmt.Errorf("work name or --selector must be specified")
+	}
+	if len(o.Workname) > 0 && len(o.Selector) > 0 {
+		return fmt.Errorf("work name and --selector can only specify one")
+	}
+	if len(o.Selector) > 0 {
+		if _, err := labels.Parse(o.Selector); err != nil {
+			return fmt.Errorf("invalid selector %s: %v", o.Selector, err)
+		}
+	}
+
+	clusters := o.ClusterOptions.AllClusters()
+	if o.UseReplicaSet {
+		if clusters.Len() > 0 || len(o.Placement) > 0 {
+			return fmt.Errorf("--clusters and --placement can not be specified with --replicaset")
+		}
+		return nil
+	}
+	if clusters.Len() > 0 && len(o.Placement) > 0 {
+		return fmt.Errorf("--clusters and --placement can only specify one")
+	}
+	if len(o.Placement) > 0 && len(strings.Split(o.Placement, "/")) != 2 {
+		return fmt.Errorf("the name of the placement %s must be in the format of <namespace>/<name>", o.Placement)
+	}
+	// the works selected by labels are deleted from all the clusters if no cluster is specified
+	if len(o.Workname) > 0 && clusters.Len() == 0 && len(o.Placement) == 0 {
+		return fmt.Errorf("--clusters or --placement must be specified")
+	}
+
 	return nil
 }
 
@@ -53,85 +90,300 @@ func (o *Options) run() error {
 	if err != nil {
 		return err
 	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(o.ClusteradmFlags.Timeout)*time.Second)
+	defer cancel()
+
+	targets, err := o.targets(ctx, workClient, clusterClient)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Fprintf(o.Streams.Out, "no %s found\n", o.kind())
+		return nil
+	}
+
+	// the works are deleted and watched in parallel, and the timeout applies to all of them
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		errs []error
+	)
+	for _, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := o.deleteTarget(ctx, t); err != nil {
+				lock.Lock()
+				errs = append(errs, err)
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// target is a ManifestWork or ManifestWorkReplicaSet to delete.
+type target struct {
+	namespace string
+	name      string
+	client    resourceClient
+}
+
+// resourceClient is the client of ManifestWorks or ManifestWorkReplicaSets in a namespace.
+type resourceClient interface {
+	get(ctx context.Context, name string) (metav1.Object, error)
+	delete(ctx context.Context, name string) error
+	patch(ctx context.Context, name string, data []byte) error
+	watch(ctx context.Context, options metav1.ListOptions) (watch.Interface, error)
+}
+
+func (o *Options) kind() string {
+	if o.UseReplicaSet {
+		return "manifestworkreplicaset"
+	}
+	return "work"
+}
+
+// describe returns the target in the messages, the works are in clusters and the manifestworkreplicasets in namespaces.
+func (o *Options) describe(t target) string {
+	if o.UseReplicaSet {
+		return fmt.Sprintf("manifestworkreplicaset %s in namespace %s", t.name, t.namespace)
+	}
+	return fmt.Sprintf("work %s in cluster %s", t.name, t.namespace)
+}
 
-	var errs []error
-	for cluster := range o.ClusterOptions.AllClusters() {
-		err := o.deleteWork(workClient, cluster)
+// targets returns the works or manifestworkreplicasets to delete, by the name in the clusters, or by the label selector.
+func (o *Options) targets(ctx context.Context, workClient workclientset.Interface, clusterClient clusterclientset.Interface) ([]target, error) {
+	if o.UseReplicaSet {
+		client := workSetClient{workClient: workClient, namespace: o.Namespace}
+		selector := o.Selector
+		if len(o.Workname) > 0 {
+			selector = workhelpers.ShardSelector(o.Workname)
+		}
+		workSets, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(o.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
 		if err != nil {
-			errs = append(errs, err)
+			return nil, err
+		}
+		if len(o.Workname) > 0 && len(workSets.Items) == 0 {
+			return []target{{namespace: o.Namespace, name: o.Workname, client: client}}, nil
+		}
+		var targets []target
+		for _, workSet := range workSets.Items {
+			targets = append(targets, target{namespace: workSet.Namespace, name: workSet.Name, client: client})
 		}
+		return targets, nil
 	}
 
-	return utilerrors.NewAggregate(errs)
+	clusters := o.ClusterOptions.AllClusters()
+	if len(o.Placement) > 0 {
+		var err error
+		if clusters, err = placementClusters(ctx, clusterClient, o.Placement); err != nil {
+			return nil, err
+		}
+		// the work is also deleted from the clusters no longer selected by the placement, which it was created in
+		// by create work with the placement before the decisions changed
+		if len(o.Workname) > 0 {
+			deployed, err := placementWorkClusters(ctx, workClient, o.Placement, o.Workname)
+			if err != nil {
+				return nil, err
+			}
+			clusters = clusters.Union(deployed)
+		}
+	}
+
+	var targets []target
+	if len(o.Workname) > 0 {
+		// the shards of a work split by its size are deleted with the work
+		for _, cluster := range sets.List(clusters) {
+			client := manifestWorkClient{workClient: workClient, cluster: cluster}
+			shards, err := workClient.WorkV1().ManifestWorks(cluster).List(ctx, metav1.ListOptions{
+				LabelSelector: workhelpers.ShardSelector(o.Workname),
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(shards.Items) == 0 {
+				targets = append(targets, target{namespace: cluster, name: o.Workname, client: client})
+			}
+			for _, shard := range shards.Items {
+				targets = append(targets, target{namespace: cluster, name: shard.Name, client: client})
+			}
+		}
+		return targets, nil
+	}
+
+	namespaces := sets.List(clusters)
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, namespace := range namespaces {
+		works, err := workClient.WorkV1().ManifestWorks(namespace).List(ctx, metav1.ListOptions{LabelSelector: o.Selector})
+		if err != nil {
+			return nil, err
+		}
+		for _, work := range works.Items {
+			targets = append(targets, target{namespace: work.Namespace, name: work.Name,
+				client: manifestWorkClient{workClient: workClient, cluster: work.Namespace}})
+		}
+	}
+	return targets, nil
 }
 
-func (o *Options) deleteWork(workClient *workclientset.Clientset, cluster string) error {
-	_, err := workClient.WorkV1().ManifestWorks(cluster).Get(context.TODO(), o.Workname, metav1.GetOptions{})
+// placementWorkClusters returns the clusters having the work or its shards created with the placement in the format
+// of <namespace>/<name>.
+func placementWorkClusters(ctx context.Context, workClient workclientset.Interface, placement, name string) (sets.Set[string], error) {
+	works, err := workClient.WorkV1().ManifestWorks(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", workhelpers.PlacementLabel, workhelpers.PlacementLabelValue(placement)),
+	})
 	if err != nil {
-		if errors.IsNotFound(err) {
-			fmt.Fprintf(o.Streams.Out, "work %s not found or is already deleted\n", o.Workname)
-			return nil
+		return nil, err
+	}
+	clusters := sets.New[string]()
+	for _, work := range works.Items {
+		if work.Name == name || work.Labels[workhelpers.ShardOfLabel] == name {
+			clusters.Insert(work.Namespace)
+		}
+	}
+	return clusters, nil
+}
+
+// placementClusters returns the clusters in the decisions of the placement in the format of <namespace>/<name>.
+func placementClusters(ctx context.Context, clusterClient clusterclientset.Interface, placement string) (sets.Set[string], error) {
+	namespace, name, _ := strings.Cut(placement, "/")
+	if _, err := clusterClient.ClusterV1beta1().Placements(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to get placement %s: %v", placement, err)
+	}
+	decisions, err := clusterClient.ClusterV1beta1().PlacementDecisions(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", clusterv1beta1.PlacementLabel, name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	clusters := sets.New[string]()
+	for _, decision := range decisions.Items {
+		for _, d := range decision.Status.Decisions {
+			clusters.Insert(d.ClusterName)
 		}
+	}
+	return clusters, nil
+}
+
+// deleteTarget deletes the target and waits until it is gone. With force, the finalizers are removed after
+// the deletion, so the target is deleted without the agent or controller cleaning up the resources.
+func (o *Options) deleteTarget(ctx context.Context, t target) error {
+	obj, err := t.client.get(ctx, t.name)
+	if errors.IsNotFound(err) {
+		fmt.Fprintf(o.Streams.Out, "%s not found or is already deleted\n", o.describe(t))
+		return nil
+	}
+	if err != nil {
 		return err
 	}
 
-	// start a goroutine to watch the delete event
-	errChannel := make(chan error)
-	timeout := 10 * time.Second
-	go func(c chan<- error) {
-		time.Sleep(timeout)
-		c <- fmt.Errorf("delete work %s timeout, failed to delete", o.Workname)
-	}(errChannel)
-
-	go func(c chan<- error) {
-		// watch until clusterset is removed
-		e := helpers.WatchUntil(
-			func() (watch.Interface, error) {
-				return workClient.WorkV1().ManifestWorks(cluster).Watch(context.TODO(), metav1.ListOptions{})
-			},
-			func(event watch.Event) bool {
-				return event.Type == watch.Deleted
-			},
-		)
-		c <- e
-
-	}(errChannel)
-
-	err = workClient.WorkV1().ManifestWorks(cluster).Delete(context.TODO(), o.Workname, metav1.DeleteOptions{})
-	if err != nil && !errors.IsNotFound(err) {
+	if o.ClusteradmFlags.DryRun {
+		fmt.Fprintf(o.Streams.Out, "%s would be deleted\n", o.describe(t))
+		return nil
+	}
+
+	if err := t.client.delete(ctx, t.name); err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
 	if o.Force {
-		// check whether work is already deleted, if not, remove the finalizer
-		work, err := workClient.WorkV1().ManifestWorks(cluster).Get(context.TODO(), o.Workname, metav1.GetOptions{})
+		current, err := t.client.get(ctx, t.name)
 		if errors.IsNotFound(err) {
-			fmt.Fprintf(o.Streams.Out, "work %s is deleted\n", o.Workname)
+			fmt.Fprintf(o.Streams.Out, "%s is deleted\n", o.describe(t))
 			return nil
 		}
-
 		if err != nil {
 			return err
 		}
-
-		// if any finalizer exists, remove it.
-		// if not, do nothing and wait for delete event.
-		if len(work.Finalizers) != 0 {
-			work.Finalizers = work.Finalizers[:0]
-
-			_, err = workClient.WorkV1().ManifestWorks(cluster).Update(context.TODO(), work, metav1.UpdateOptions{})
-			if err != nil {
+		if finalizers := current.GetFinalizers(); len(finalizers) > 0 {
+			if err := t.client.patch(ctx, t.name, []byte(`{"metadata":{"finalizers":null}}`)); err != nil && !errors.IsNotFound(err) {
 				return err
 			}
+			fmt.Fprintf(o.Streams.Out, "%s is forced to be deleted, finalizers removed: %s\n",
+				o.describe(t), strings.Join(finalizers, ","))
 		}
 	}
 
-	// handle the error of watch function
-	if err = <-errChannel; err != nil {
-		close(errChannel)
+	if err := waitForDeleted(ctx, t, obj.GetResourceVersion()); err != nil {
+		if wait.Interrupted(err) {
+			return fmt.Errorf("timeout waiting for %s to be deleted", o.describe(t))
+		}
 		return err
 	}
 
-	fmt.Fprintf(o.Streams.Out, "work %s in cluster %s is deleted\n", o.Workname, cluster)
+	fmt.Fprintf(o.Streams.Out, "%s is deleted\n", o.describe(t))
 	return nil
 }
+
+// waitForDeleted watches the target by its name from the resource version, so the deletion between the get
+// and the watch is not missed, until it is deleted or the context is done.
+func waitForDeleted(ctx context.Context, t target, resourceVersion string) error {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", t.name).String()
+	watcher, err := watchtools.NewRetryWatcherWithContext(ctx, resourceVersion, &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return t.client.watch(ctx, options)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = watchtools.UntilWithoutRetry(ctx, watcher, func(event watch.Event) (bool, error) {
+		return event.Type == watch.Deleted, nil
+	})
+	return err
+}
+
+type manifestWorkClient struct {
+	workClient workclientset.Interface
+	cluster    string
+}
+
+func (c manifestWorkClient) get(ctx context.Context, name string) (metav1.Object, error) {
+	return c.workClient.WorkV1().ManifestWorks(c.cluster).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c manifestWorkClient) delete(ctx context.Context, name string) error {
+	return c.workClient.WorkV1().ManifestWorks(c.cluster).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (c manifestWorkClient) patch(ctx context.Context, name string, data []byte) error {
+	_, err := c.workClient.WorkV1().ManifestWorks(c.cluster).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+func (c manifestWorkClient) watch(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+	return c.workClient.WorkV1().ManifestWorks(c.cluster).Watch(ctx, options)
+}
+
+type workSetClient struct {
+	workClient workclientset.Interface
+	namespace  string
+}
+
+func (c workSetClient) get(ctx context.Context, name string) (metav1.Object, error) {
+	return c.workClient.WorkV1alpha1().ManifestWorkReplicaSets(c.namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (c workSetClient) delete(ctx context.Context, name string) error {
+	return c.workClient.WorkV1alpha1().ManifestWorkReplicaSets(c.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (c workSetClient) patch(ctx context.Context, name string, data []byte) error {
+	_, err := c.workClient.WorkV1alpha1().ManifestWorkReplicaSets(c.namespace).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+func (c workSetClient) watch(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+	return c.workClient.WorkV1alpha1().ManifestWorkReplicaSets(c.namespace).Watch(ctx, options)
+}
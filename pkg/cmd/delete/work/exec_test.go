@@ -0,0 +1,153 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	workfake "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+	workapiv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
+)
+
+func newWork(cluster, name string, labels map[string]string) *workapiv1.ManifestWork {
+	return &workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cluster, Labels: labels},
+	}
+}
+
+func TestTargets(t *testing.T) {
+	placementLabels := map[string]string{workhelpers.PlacementLabel: "default.placement1"}
+	placementObjs := []runtime.Object{
+		&clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default"}},
+		&clusterv1beta1.PlacementDecision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "placement1-decision-1",
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1beta1.PlacementLabel: "placement1"},
+			},
+			Status: clusterv1beta1.PlacementDecisionStatus{
+				Decisions: []clusterv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+			},
+		},
+	}
+
+	cases := []struct {
+		name         string
+		options      *Options
+		works        []runtime.Object
+		clusterObjs  []runtime.Object
+		expected     []string
+		expectedErrs bool
+	}{
+		{
+			name:     "work in the clusters",
+			options:  &Options{Workname: "work1", ClusterOptions: &genericclioptionsclusteradm.ClusterOption{Clusters: []string{"cluster1", "cluster2"}}},
+			works:    []runtime.Object{newWork("cluster1", "work1", nil), newWork("cluster3", "work1", nil)},
+			expected: []string{"cluster1/work1", "cluster2/work1"},
+		},
+		{
+			name:    "shards of the work",
+			options: &Options{Workname: "work1", ClusterOptions: &genericclioptionsclusteradm.ClusterOption{Clusters: []string{"cluster1"}}},
+			works: []runtime.Object{
+				newWork("cluster1", "work1-0", map[string]string{workhelpers.ShardOfLabel: "work1"}),
+				newWork("cluster1", "work1-1", map[string]string{workhelpers.ShardOfLabel: "work1"}),
+			},
+			expected: []string{"cluster1/work1-0", "cluster1/work1-1"},
+		},
+		{
+			name:    "work in the clusters selected by the placement, and created by it before",
+			options: &Options{Workname: "work1", Placement: "default/placement1", ClusterOptions: &genericclioptionsclusteradm.ClusterOption{}},
+			works: []runtime.Object{
+				newWork("cluster1", "work1", placementLabels),
+				newWork("cluster2", "work1", placementLabels),
+				newWork("cluster3", "work1", nil),
+				newWork("cluster4", "work1", map[string]string{workhelpers.PlacementLabel: "default.placement2"}),
+				newWork("cluster5", "work2", placementLabels),
+				newWork("cluster6", "work1-0", map[string]string{
+					workhelpers.PlacementLabel: "default.placement1", workhelpers.ShardOfLabel: "work1"}),
+			},
+			clusterObjs: placementObjs,
+			expected:    []string{"cluster1/work1", "cluster2/work1", "cluster6/work1-0"},
+		},
+		{
+			name:         "placement not found",
+			options:      &Options{Workname: "work1", Placement: "default/placement2", ClusterOptions: &genericclioptionsclusteradm.ClusterOption{}},
+			clusterObjs:  placementObjs,
+			expectedErrs: true,
+		},
+		{
+			name:    "works selected by labels in all the clusters",
+			options: &Options{Selector: "app=web", ClusterOptions: &genericclioptionsclusteradm.ClusterOption{}},
+			works: []runtime.Object{
+				newWork("cluster1", "work1", map[string]string{"app": "web"}),
+				newWork("cluster2", "work2", map[string]string{"app": "web"}),
+				newWork("cluster2", "work3", map[string]string{"app": "db"}),
+			},
+			expected: []string{"cluster1/work1", "cluster2/work2"},
+		},
+		{
+			name:    "works selected by labels in the clusters selected by the placement",
+			options: &Options{Selector: "app=web", Placement: "default/placement1", ClusterOptions: &genericclioptionsclusteradm.ClusterOption{}},
+			works: []runtime.Object{
+				newWork("cluster1", "work1", map[string]string{"app": "web"}),
+				newWork("cluster2", "work2", map[string]string{"app": "web"}),
+			},
+			clusterObjs: placementObjs,
+			expected:    []string{"cluster1/work1"},
+		},
+		{
+			name:    "manifestworkreplicaset and its shards",
+			options: &Options{Workname: "workset1", Namespace: "default", UseReplicaSet: true, ClusterOptions: &genericclioptionsclusteradm.ClusterOption{}},
+			works: []runtime.Object{
+				&workapiv1alpha1.ManifestWorkReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name: "workset1-0", Namespace: "default", Labels: map[string]string{workhelpers.ShardOfLabel: "workset1"}}},
+				&workapiv1alpha1.ManifestWorkReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name: "workset1-1", Namespace: "default", Labels: map[string]string{workhelpers.ShardOfLabel: "workset1"}}},
+			},
+			expected: []string{"default/workset1-0", "default/workset1-1"},
+		},
+		{
+			name:     "manifestworkreplicaset",
+			options:  &Options{Workname: "workset1", Namespace: "default", UseReplicaSet: true, ClusterOptions: &genericclioptionsclusteradm.ClusterOption{}},
+			expected: []string{"default/workset1"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			workClient := workfake.NewSimpleClientset(c.works...)
+			clusterClient := clusterfake.NewSimpleClientset(c.clusterObjs...)
+
+			targets, err := c.options.targets(context.TODO(), workClient, clusterClient)
+			if c.expectedErrs {
+				if err == nil {
+					t.Errorf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var actual []string
+			for _, target := range targets {
+				actual = append(actual, target.namespace+"/"+target.name)
+			}
+			sort.Strings(actual)
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected targets %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -19,12 +19,24 @@ type Options struct {
 	Workname string
 
 	Force bool
+
+	// Selector is the label selector of the works to delete
+	Selector string
+
+	// Placement is the placement in the format of <namespace>/<name>, whose selected clusters the work is deleted from
+	Placement string
+
+	// UseReplicaSet is to delete the ManifestWorkReplicaSets instead of the ManifestWorks
+	UseReplicaSet bool
+
+	// Namespace is the namespace of the ManifestWorkReplicaSets
+	Namespace string
 }
 
 func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
 	return &Options{
 		ClusteradmFlags: clusteradmFlags,
 		Streams:         streams,
-		ClusterOptions:  genericclioptionsclusteradm.NewClusterOption(),
+		ClusterOptions:  genericclioptionsclusteradm.NewClusterOption().AllowUnset(),
 	}
 }
@@ -0,0 +1,145 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("at least one cluster must be specified")
+	}
+	o.Clusters = args
+	return nil
+}
+
+func (o *Options) validate() error {
+	return o.ClusteradmFlags.ValidateHub()
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	for _, cluster := range o.Clusters {
+		changed, err := clusterhelpers.Cordon(ctx, clusterClient, cluster, true, o.ClusteradmFlags.DryRun)
+		if err != nil {
+			return err
+		}
+		if changed {
+			fmt.Fprintf(o.Streams.Out, "Cluster %s is cordoned\n", cluster)
+		} else {
+			fmt.Fprintf(o.Streams.Out, "Cluster %s is already cordoned\n", cluster)
+		}
+	}
+
+	if o.ClusteradmFlags.DryRun {
+		placements, err := placementsSelecting(ctx, clusterClient, o.Clusters)
+		if err != nil {
+			return err
+		}
+		o.printPlacements(placements)
+		return nil
+	}
+
+	return o.waitForDrained(ctx, clusterClient)
+}
+
+// waitForDrained waits until no PlacementDecision references the clusters, and prints the placements
+// selecting the clusters when they change.
+func (o *Options) waitForDrained(ctx context.Context, clusterClient clusterclientset.Interface) error {
+	var placements map[string]sets.Set[string]
+	timeout := time.Duration(o.ClusteradmFlags.Timeout) * time.Second
+	err := wait.PollUntilContextTimeout(ctx, o.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		current, err := placementsSelecting(ctx, clusterClient, o.Clusters)
+		if err != nil {
+			return false, err
+		}
+		if !equalPlacements(placements, current) {
+			o.printPlacements(current)
+		}
+		placements = current
+		return len(current) == 0, nil
+	})
+	if wait.Interrupted(err) {
+		var remaining []string
+		for _, cluster := range sets.List(sets.KeySet(placements)) {
+			remaining = append(remaining, fmt.Sprintf("%s (%s)", cluster, strings.Join(sets.List(placements[cluster]), ",")))
+		}
+		return fmt.Errorf("timeout waiting for the clusters to be drained, still selected by placements: %s. "+
+			"The placements may tolerate the taint %s", strings.Join(remaining, "; "), clusterhelpers.CordonTaintKey)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Streams.Out, "Cluster %s drained\n", strings.Join(o.Clusters, ","))
+	return nil
+}
+
+func (o *Options) printPlacements(placements map[string]sets.Set[string]) {
+	for _, cluster := range o.Clusters {
+		if placements[cluster].Len() == 0 {
+			fmt.Fprintf(o.Streams.Out, "Cluster %s is not selected by any placement\n", cluster)
+			continue
+		}
+		fmt.Fprintf(o.Streams.Out, "Cluster %s is selected by placements: %s\n",
+			cluster, strings.Join(sets.List(placements[cluster]), ","))
+	}
+}
+
+// placementsSelecting returns the placements in the format of {namespace}/{name} per cluster, whose
+// decisions reference the cluster. The clusters not selected by any placement are not in the map.
+func placementsSelecting(ctx context.Context, clusterClient clusterclientset.Interface, clusters []string) (map[string]sets.Set[string], error) {
+	decisions, err := clusterClient.ClusterV1beta1().PlacementDecisions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := sets.New[string](clusters...)
+	placements := map[string]sets.Set[string]{}
+	for _, decision := range decisions.Items {
+		placement := fmt.Sprintf("%s/%s", decision.Namespace, decision.Labels[clusterv1beta1.PlacementLabel])
+		for _, d := range decision.Status.Decisions {
+			if !names.Has(d.ClusterName) {
+				continue
+			}
+			if _, ok := placements[d.ClusterName]; !ok {
+				placements[d.ClusterName] = sets.New[string]()
+			}
+			placements[d.ClusterName].Insert(placement)
+		}
+	}
+	return placements, nil
+}
+
+func equalPlacements(a, b map[string]sets.Set[string]) bool {
+	if a == nil || len(a) != len(b) {
+		return false
+	}
+	for cluster, placements := range a {
+		if !placements.Equal(b[cluster]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	Streams genericiooptions.IOStreams
+
+	Clusters []string
+
+	// pollInterval is the interval to check the decisions of the placements
+	pollInterval time.Duration
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+		pollInterval:    2 * time.Second,
+	}
+}
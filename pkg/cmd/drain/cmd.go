@@ -0,0 +1,22 @@
+// Copyright Contributors to the Open Cluster Management project
+package drain
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/cmd/drain/cluster"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping the drain subcommands
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "drain managed clusters in preparation for maintenance",
+	}
+
+	cmd.AddCommand(cluster.NewCmd(clusteradmFlags, streams))
+
+	return cmd
+}
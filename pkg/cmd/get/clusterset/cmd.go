@@ -14,6 +14,9 @@ import (
 var example = `
 # Get clustersets
 %[1]s get clustersets
+
+# Get the bindings of clustersets and whether they are bound
+%[1]s get clustersets --bindings
 `
 
 // NewCmd...
@@ -45,6 +48,8 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 		},
 	}
 
+	cmd.Flags().BoolVar(&o.Bindings, "bindings", false, "Show the namespaces the clustersets are bound to, with the status of the Bound condition")
+
 	o.printer.AddFlag(cmd.Flags())
 
 	return cmd
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/klog/v2"
 
 	v1 "open-cluster-management.io/api/cluster/v1"
 
@@ -57,43 +56,55 @@ func (o *Options) validate(args []string) (err error) {
 }
 
 func (o *Options) run() (err error) {
+	if o.Bindings {
+		bindings, err := o.Client.ClusterV1beta2().ManagedClusterSetBindings(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		o.printer.WithTreeConverter(convertBindingsToTree).WithTableConverter(convertBindingsToTable)
+		return o.printer.Print(o.Streams, bindings)
+	}
+
 	clustersets, err := o.Client.ClusterV1beta2().ManagedClusterSets().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		return err
 	}
 
-	o.printer.WithTreeConverter(o.convertToTree).WithTableConverter(o.converToTable)
-
-	return o.printer.Print(o.Streams, clustersets)
-}
-
-func (o *Options) convertToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
-	bindingMap := map[string][]string{}
+	// the bindings and clusters are looked up before printing, so the errors are returned rather than
+	// failing in the converters
 	bindings, err := o.Client.ClusterV1beta2().ManagedClusterSetBindings(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		panic(err)
+		return err
 	}
+	o.boundNamespaces = map[string][]string{}
 	for _, binding := range bindings.Items {
-		if _, ok := bindingMap[binding.Spec.ClusterSet]; !ok {
-			bindingMap[binding.Spec.ClusterSet] = []string{}
-		}
-
-		bindingMap[binding.Spec.ClusterSet] = append(bindingMap[binding.Spec.ClusterSet], binding.Namespace)
+		o.boundNamespaces[binding.Spec.ClusterSet] = append(o.boundNamespaces[binding.Spec.ClusterSet], binding.Namespace)
 	}
 
 	getter := &clusterGetter{client: o.Client}
+	o.clusters = map[string][]string{}
+	for i := range clustersets.Items {
+		clusters, err := getter.listClustersByClusterSet(&clustersets.Items[i])
+		if err != nil {
+			return fmt.Errorf("failed to list clusters in clusterset %s: %v", clustersets.Items[i].Name, err)
+		}
+		o.clusters[clustersets.Items[i].Name] = clusters
+	}
 
+	o.printer.WithTreeConverter(o.convertToTree).WithTableConverter(o.converToTable)
+
+	return o.printer.Print(o.Streams, clustersets)
+}
+
+func (o *Options) convertToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
 	if csList, ok := obj.(*clusterapiv1beta2.ManagedClusterSetList); ok {
 		for _, clusterset := range csList.Items {
-			boundNs, status, managedNs := getFileds(clusterset, bindingMap[clusterset.Name])
-			clusters, err := getter.listClustersByClusterSet(&clusterset)
-			if err != nil {
-				klog.Fatalf("Failed to list cluster in clusterset %s: %v", clusterset.Name, err)
-			}
+			boundNs, status, managedNs := getFileds(clusterset, o.boundNamespaces[clusterset.Name])
 			mp := make(map[string]interface{})
 			mp[".BoundNamespace"] = boundNs
 			mp[".Status"] = status
-			mp[".Clusters"] = clusters
+			mp[".Selector"] = selectorOf(&clusterset)
+			mp[".Clusters"] = o.clusters[clusterset.Name]
 			mp[".ManagedNamespaces"] = managedNs
 			tree.AddFileds(clusterset.Name, &mp)
 		}
@@ -103,22 +114,11 @@ func (o *Options) convertToTree(obj runtime.Object, tree *printer.TreePrinter) *
 }
 
 func (o *Options) converToTable(obj runtime.Object) *metav1.Table {
-	bindingMap := map[string][]string{}
-	bindings, err := o.Client.ClusterV1beta2().ManagedClusterSetBindings(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
-	if err != nil {
-		panic(err)
-	}
-	for _, binding := range bindings.Items {
-		if _, ok := bindingMap[binding.Spec.ClusterSet]; !ok {
-			bindingMap[binding.Spec.ClusterSet] = []string{}
-		}
-
-		bindingMap[binding.Spec.ClusterSet] = append(bindingMap[binding.Spec.ClusterSet], binding.Namespace)
-	}
-
 	table := &metav1.Table{
 		ColumnDefinitions: []metav1.TableColumnDefinition{
 			{Name: "Name", Type: "string"},
+			{Name: "Selector", Type: "string"},
+			{Name: "Clusters", Type: "string"},
 			{Name: "Bound Namespaces", Type: "string"},
 			{Name: "Status", Type: "string"},
 			{Name: "Managed Namespaces", Type: "string"},
@@ -128,10 +128,11 @@ func (o *Options) converToTable(obj runtime.Object) *metav1.Table {
 
 	if csList, ok := obj.(*clusterapiv1beta2.ManagedClusterSetList); ok {
 		for _, clusterset := range csList.Items {
-			boundNs, status, managedNs := getFileds(clusterset, bindingMap[clusterset.Name])
+			boundNs, status, managedNs := getFileds(clusterset, o.boundNamespaces[clusterset.Name])
 			managedNsStr := strings.Join(managedNs, ",")
 			row := metav1.TableRow{
-				Cells:  []interface{}{clusterset.Name, boundNs, status, managedNsStr},
+				Cells: []interface{}{clusterset.Name, selectorOf(&clusterset), strings.Join(o.clusters[clusterset.Name], ","),
+					boundNs, status, managedNsStr},
 				Object: runtime.RawExtension{Object: &clusterset},
 			}
 
@@ -158,6 +159,71 @@ func getFileds(clusterset clusterapiv1beta2.ManagedClusterSet, bindings []string
 	return
 }
 
+func convertBindingsToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
+	if bindingList, ok := obj.(*clusterapiv1beta2.ManagedClusterSetBindingList); ok {
+		bindings := map[string]map[string]interface{}{}
+		for _, binding := range bindingList.Items {
+			if _, ok := bindings[binding.Spec.ClusterSet]; !ok {
+				bindings[binding.Spec.ClusterSet] = map[string]interface{}{}
+			}
+			status, reason := boundStatus(&binding)
+			bindings[binding.Spec.ClusterSet]["."+binding.Namespace] = fmt.Sprintf("Bound=%s %s", status, reason)
+		}
+		for clusterset, mp := range bindings {
+			tree.AddFileds(clusterset, &mp)
+		}
+	}
+	return tree
+}
+
+func convertBindingsToTable(obj runtime.Object) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "ClusterSet", Type: "string"},
+			{Name: "Namespace", Type: "string"},
+			{Name: "Bound", Type: "string"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+		Rows: []metav1.TableRow{},
+	}
+
+	if bindingList, ok := obj.(*clusterapiv1beta2.ManagedClusterSetBindingList); ok {
+		for _, binding := range bindingList.Items {
+			status, reason := boundStatus(&binding)
+			message := ""
+			if cond := meta.FindStatusCondition(binding.Status.Conditions, clusterapiv1beta2.ClusterSetBindingBoundType); cond != nil {
+				message = cond.Message
+			}
+			table.Rows = append(table.Rows, metav1.TableRow{
+				Cells:  []interface{}{binding.Spec.ClusterSet, binding.Namespace, status, reason, message},
+				Object: runtime.RawExtension{Object: &binding},
+			})
+		}
+	}
+
+	return table
+}
+
+// boundStatus returns the status and reason of the Bound condition of the binding, Unknown if the
+// condition is not reported yet.
+func boundStatus(binding *clusterapiv1beta2.ManagedClusterSetBinding) (string, string) {
+	cond := meta.FindStatusCondition(binding.Status.Conditions, clusterapiv1beta2.ClusterSetBindingBoundType)
+	if cond == nil {
+		return string(metav1.ConditionUnknown), ""
+	}
+	return string(cond.Status), cond.Reason
+}
+
+// selectorOf returns the selector type of the clusterset, and the label selector of a LabelSelector clusterset.
+func selectorOf(clusterset *clusterapiv1beta2.ManagedClusterSet) string {
+	selector := clusterset.Spec.ClusterSelector
+	if selector.SelectorType != clusterapiv1beta2.LabelSelector {
+		return string(clusterapiv1beta2.ExclusiveClusterSetLabel)
+	}
+	return fmt.Sprintf("%s(%s)", selector.SelectorType, metav1.FormatLabelSelector(selector.LabelSelector))
+}
+
 type clusterGetter struct {
 	client clusterclientset.Interface
 }
@@ -20,6 +20,15 @@ type Options struct {
 	printer *printer.PrinterOption
 
 	Client *clusterclientset.Clientset
+
+	// Bindings prints the bindings of the clustersets with their Bound condition instead
+	Bindings bool
+
+	// boundNamespaces are the namespaces bound to each clusterset
+	boundNamespaces map[string][]string
+
+	// clusters are the clusters selected by each clusterset
+	clusters map[string][]string
 }
 
 func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
@@ -8,11 +8,13 @@ import (
 	"open-cluster-management.io/clusteradm/pkg/cmd/get/addon"
 	"open-cluster-management.io/clusteradm/pkg/cmd/get/cluster"
 	"open-cluster-management.io/clusteradm/pkg/cmd/get/clusterset"
+	"open-cluster-management.io/clusteradm/pkg/cmd/get/events"
 	"open-cluster-management.io/clusteradm/pkg/cmd/get/hubinfo"
 	"open-cluster-management.io/clusteradm/pkg/cmd/get/klusterletinfo"
 	"open-cluster-management.io/clusteradm/pkg/cmd/get/placement"
 	"open-cluster-management.io/clusteradm/pkg/cmd/get/token"
 	"open-cluster-management.io/clusteradm/pkg/cmd/get/work"
+	"open-cluster-management.io/clusteradm/pkg/cmd/get/workreplicaset"
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
 )
 
@@ -30,7 +32,9 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 	cmd.AddCommand(hubinfo.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(klusterletinfo.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(work.NewCmd(clusteradmFlags, streams))
+	cmd.AddCommand(workreplicaset.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(placement.NewCmd(clusteradmFlags, streams))
+	cmd.AddCommand(events.NewCmd(clusteradmFlags, streams))
 
 	return cmd
 }
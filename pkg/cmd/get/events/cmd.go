@@ -0,0 +1,64 @@
+// Copyright Contributors to the Open Cluster Management project
+package events
+
+import (
+	"fmt"
+
+	"open-cluster-management.io/clusteradm/pkg/config"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var example = `
+# Get events of the OCM objects of a cluster on the hub
+%[1]s get events --cluster cluster1
+# Get events of a cluster on both the hub and the managed cluster
+%[1]s get events --cluster cluster1 --managed-cluster-kubeconfig ~/.kube/cluster1.kubeconfig
+# Get only warning events
+%[1]s get events --cluster cluster1 --types Warning
+`
+
+// NewCmd...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:          "events",
+		Short:        "get events of a managed cluster",
+		Long:         "get events of the ManagedCluster, ManagedClusterAddOns, ManifestWorks and CSRs of a managed cluster on the hub, and optionally of the klusterlet on the managed cluster, merged in chronological order",
+		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(args); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Cluster, "cluster", "c", "", "Name of the managed cluster")
+	cmd.Flags().StringVar(&o.ManagedKubeconfigFile, "managed-cluster-kubeconfig", "",
+		"The kubeconfig of the managed cluster, if set the events of the klusterlet are included")
+	cmd.Flags().StringVar(&o.AgentNamespace, "agent-namespace", config.ManagedClusterNamespace,
+		"The namespace of the klusterlet agents on the managed cluster")
+	cmd.Flags().StringSliceVar(&o.Types, "types", []string{}, "Only show events of the given types, e.g. Warning")
+
+	o.printer.AddFlag(cmd.Flags())
+
+	return cmd
+}
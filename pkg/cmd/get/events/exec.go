@@ -0,0 +1,280 @@
+// Copyright Contributors to the Open Cluster Management project
+package events
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+)
+
+const (
+	sideHub     = "hub"
+	sideManaged = "managed"
+
+	// clusterNameLabel is set on the CSRs created by the registration agent of a managed cluster
+	clusterNameLabel = "open-cluster-management.io/cluster-name"
+)
+
+// hubEventKinds are the kinds of objects whose events are collected from the cluster namespace on the hub
+var hubEventKinds = sets.New[string]("ManagedCluster", "ManagedClusterAddOn", "ManifestWork")
+
+func (o *Options) complete(_ *cobra.Command, _ []string) (err error) {
+	o.printer.Competele()
+
+	return nil
+}
+
+func (o *Options) validate(args []string) (err error) {
+	err = o.ClusteradmFlags.ValidateHub()
+	if err != nil {
+		return err
+	}
+
+	if len(args) != 0 {
+		return fmt.Errorf("there should be no argument")
+	}
+
+	if len(o.Cluster) == 0 {
+		return fmt.Errorf("--cluster must be set")
+	}
+
+	err = o.printer.Validate()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *Options) run() (err error) {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	var managedKubeClient kubernetes.Interface
+	if len(o.ManagedKubeconfigFile) > 0 {
+		managedConfig, err := clientcmd.BuildConfigFromFlags("", o.ManagedKubeconfigFile)
+		if err != nil {
+			return err
+		}
+		managedKubeClient, err = kubernetes.NewForConfig(managedConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	eventList, err := o.listEvents(clusterClient, kubeClient, managedKubeClient)
+	if err != nil {
+		return err
+	}
+
+	o.printer.WithTreeConverter(o.convertToTree).WithTableConverter(o.converToTable)
+
+	return o.printer.Print(o.Streams, eventList)
+}
+
+// listEvents returns the events of the managed cluster on the hub, and on the managed cluster if its client is
+// not nil, sorted by the time. An event collected from both the hub and the managed cluster is kept once.
+func (o *Options) listEvents(
+	clusterClient clusterclientset.Interface,
+	kubeClient, managedKubeClient kubernetes.Interface) (*corev1.EventList, error) {
+	if _, err := clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), o.Cluster, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	eventList := &corev1.EventList{Items: []corev1.Event{}}
+
+	hubEvents, err := o.getHubEvents(kubeClient)
+	if err != nil {
+		return nil, err
+	}
+	o.addEvents(eventList, hubEvents, sideHub)
+
+	if managedKubeClient != nil {
+		managedEvents, err := o.getManagedClusterEvents(managedKubeClient)
+		if err != nil {
+			return nil, err
+		}
+		o.addEvents(eventList, managedEvents, sideManaged)
+	}
+
+	sort.SliceStable(eventList.Items, func(i, j int) bool {
+		return eventTime(eventList.Items[i]).Before(eventTime(eventList.Items[j]))
+	})
+	return eventList, nil
+}
+
+// getHubEvents returns the events of the ManagedCluster, ManagedClusterAddOns, ManifestWorks and
+// CSRs of the managed cluster on the hub.
+func (o *Options) getHubEvents(kubeClient kubernetes.Interface) ([]corev1.Event, error) {
+	var events []corev1.Event
+
+	namespaced, err := kubeClient.CoreV1().Events(o.Cluster).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range namespaced.Items {
+		if hubEventKinds.Has(event.InvolvedObject.Kind) {
+			events = append(events, event)
+		}
+	}
+
+	// events of the cluster scoped objects may be recorded in any namespace
+	clusterEvents, err := listEventsOf(kubeClient, "ManagedCluster", o.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, clusterEvents...)
+
+	csrs, err := kubeClient.CertificatesV1().CertificateSigningRequests().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", clusterNameLabel, o.Cluster),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, csr := range csrs.Items {
+		csrEvents, err := listEventsOf(kubeClient, "CertificateSigningRequest", csr.Name)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, csrEvents...)
+	}
+
+	return events, nil
+}
+
+// getManagedClusterEvents returns the events of the klusterlet and of the objects in the agent namespace
+// on the managed cluster.
+func (o *Options) getManagedClusterEvents(kubeClient kubernetes.Interface) ([]corev1.Event, error) {
+	agentEvents, err := kubeClient.CoreV1().Events(o.AgentNamespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	klusterletEvents, err := listEventsOf(kubeClient, "Klusterlet", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(agentEvents.Items, klusterletEvents...), nil
+}
+
+// listEventsOf lists the events of the objects with the given kind, and name if it is not empty, in all namespaces.
+func listEventsOf(kubeClient kubernetes.Interface, kind, name string) ([]corev1.Event, error) {
+	selector := fields.Set{"involvedObject.kind": kind}
+	if len(name) > 0 {
+		selector["involvedObject.name"] = name
+	}
+	events, err := kubeClient.CoreV1().Events(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: selector.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events.Items, nil
+}
+
+// addEvents appends the events not yet collected and matching the requested types to the list.
+func (o *Options) addEvents(eventList *corev1.EventList, events []corev1.Event, side string) {
+	types := sets.New[string](o.Types...)
+	for _, event := range events {
+		if types.Len() > 0 && !types.Has(event.Type) {
+			continue
+		}
+		if _, ok := o.sides[event.UID]; ok {
+			continue
+		}
+		o.sides[event.UID] = side
+		eventList.Items = append(eventList.Items, event)
+	}
+}
+
+func (o *Options) convertToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
+	if eventList, ok := obj.(*corev1.EventList); ok {
+		for _, event := range eventList.Items {
+			mp := make(map[string]interface{})
+			mp[fmt.Sprintf(".%s.%s", eventTime(event).UTC().Format(time.RFC3339), event.Reason)] = event.Message
+			tree.AddFileds(fmt.Sprintf("%s.%s.%s", o.sides[event.UID], event.InvolvedObject.Kind, event.InvolvedObject.Name), &mp)
+		}
+	}
+	return tree
+}
+
+func (o *Options) converToTable(obj runtime.Object) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Last Seen", Type: "string"},
+			{Name: "Side", Type: "string"},
+			{Name: "Kind", Type: "string"},
+			{Name: "Object", Type: "string"},
+			{Name: "Type", Type: "string"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+		Rows: []metav1.TableRow{},
+	}
+
+	if eventList, ok := obj.(*corev1.EventList); ok {
+		for _, event := range eventList.Items {
+			object := event.InvolvedObject.Name
+			if len(event.InvolvedObject.Namespace) > 0 {
+				object = event.InvolvedObject.Namespace + "/" + object
+			}
+			row := metav1.TableRow{
+				Cells: []interface{}{
+					duration.HumanDuration(time.Since(eventTime(event))),
+					o.sides[event.UID],
+					event.InvolvedObject.Kind,
+					object,
+					event.Type,
+					event.Reason,
+					event.Message,
+				},
+				Object: runtime.RawExtension{Object: &event},
+			}
+
+			table.Rows = append(table.Rows, row)
+		}
+	}
+	return table
+}
+
+// eventTime returns the time the event was last observed, falling back to the older fields for
+// events recorded with the legacy or the new events API.
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright Contributors to the Open Cluster Management project
+package events
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+var baseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newEvent(uid, namespace, kind, name, eventType string, minutes int) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "event-" + uid, Namespace: namespace, UID: types.UID(uid)},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: name},
+		Type:           eventType,
+		LastTimestamp:  metav1.NewTime(baseTime.Add(time.Duration(minutes) * time.Minute)),
+	}
+}
+
+func newCSR(name, cluster string) *certificatesv1.CertificateSigningRequest {
+	return &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{clusterNameLabel: cluster}},
+	}
+}
+
+// newKubeClient returns a fake client filtering the events by the involved object, as the fake object tracker
+// ignores the field selectors.
+func newKubeClient(objs ...runtime.Object) *kubefake.Clientset {
+	client := kubefake.NewSimpleClientset(objs...)
+	client.PrependReactor("list", "events", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		selector := action.(clienttesting.ListAction).GetListRestrictions().Fields
+		if selector == nil || selector.Empty() {
+			return false, nil, nil
+		}
+		obj, err := client.Tracker().List(corev1.SchemeGroupVersion.WithResource("events"),
+			corev1.SchemeGroupVersion.WithKind("Event"), action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		filtered := &corev1.EventList{}
+		for _, event := range obj.(*corev1.EventList).Items {
+			if selector.Matches(fields.Set{
+				"involvedObject.kind": event.InvolvedObject.Kind,
+				"involvedObject.name": event.InvolvedObject.Name,
+			}) {
+				filtered.Items = append(filtered.Items, event)
+			}
+		}
+		return true, filtered, nil
+	})
+	return client
+}
+
+func TestListEvents(t *testing.T) {
+	hubObjs := []runtime.Object{
+		newCSR("csr-1", "cluster1"),
+		newCSR("csr-2", "cluster2"),
+		// the event of the managed cluster in its namespace is also listed by the involved object
+		newEvent("1", "cluster1", "ManagedCluster", "cluster1", corev1.EventTypeNormal, 1),
+		newEvent("2", "cluster1", "ManifestWork", "work1", corev1.EventTypeWarning, 2),
+		newEvent("3", "cluster1", "Pod", "pod1", corev1.EventTypeWarning, 3),
+		newEvent("4", "default", "ManagedCluster", "cluster1", corev1.EventTypeNormal, 4),
+		newEvent("5", "default", "ManagedCluster", "cluster2", corev1.EventTypeNormal, 5),
+		newEvent("6", "default", "CertificateSigningRequest", "csr-1", corev1.EventTypeNormal, 0),
+		newEvent("7", "default", "CertificateSigningRequest", "csr-2", corev1.EventTypeNormal, 6),
+	}
+	managedObjs := []runtime.Object{
+		newEvent("8", "open-cluster-management-agent", "Deployment", "klusterlet-agent", corev1.EventTypeWarning, 7),
+		newEvent("9", "default", "Klusterlet", "klusterlet", corev1.EventTypeNormal, 8),
+		newEvent("10", "default", "Pod", "pod2", corev1.EventTypeNormal, 9),
+		// the hub is also the managed cluster, the event is collected from the hub
+		newEvent("1", "cluster1", "ManagedCluster", "cluster1", corev1.EventTypeNormal, 1),
+	}
+
+	cases := []struct {
+		name          string
+		types         []string
+		managed       bool
+		expectedUIDs  []string
+		expectedSides map[string]string
+	}{
+		{
+			name:         "hub events",
+			expectedUIDs: []string{"6", "1", "2", "4"},
+			expectedSides: map[string]string{
+				"6": sideHub, "1": sideHub, "2": sideHub, "4": sideHub,
+			},
+		},
+		{
+			name:         "hub and managed cluster events",
+			managed:      true,
+			expectedUIDs: []string{"6", "1", "2", "4", "8", "9"},
+			expectedSides: map[string]string{
+				"6": sideHub, "1": sideHub, "2": sideHub, "4": sideHub, "8": sideManaged, "9": sideManaged,
+			},
+		},
+		{
+			name:         "warning events",
+			types:        []string{corev1.EventTypeWarning},
+			managed:      true,
+			expectedUIDs: []string{"2", "8"},
+			expectedSides: map[string]string{
+				"2": sideHub, "8": sideManaged,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			o := &Options{
+				Cluster:        "cluster1",
+				AgentNamespace: "open-cluster-management-agent",
+				Types:          c.types,
+				sides:          map[types.UID]string{},
+			}
+			clusterClient := clusterfake.NewSimpleClientset(&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}})
+			var managedKubeClient kubernetes.Interface
+			if c.managed {
+				managedKubeClient = newKubeClient(managedObjs...)
+			}
+
+			eventList, err := o.listEvents(clusterClient, newKubeClient(hubObjs...), managedKubeClient)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var uids []string
+			for _, event := range eventList.Items {
+				uids = append(uids, string(event.UID))
+			}
+			if !reflect.DeepEqual(uids, c.expectedUIDs) {
+				t.Errorf("expected events %v, got %v", c.expectedUIDs, uids)
+			}
+			sides := map[string]string{}
+			for uid, side := range o.sides {
+				sides[string(uid)] = side
+			}
+			if !reflect.DeepEqual(sides, c.expectedSides) {
+				t.Errorf("expected sides %v, got %v", c.expectedSides, sides)
+			}
+		})
+	}
+}
+
+func TestListEventsClusterNotFound(t *testing.T) {
+	o := &Options{Cluster: "cluster1", sides: map[types.UID]string{}}
+	if _, err := o.listEvents(clusterfake.NewSimpleClientset(), newKubeClient(), nil); err == nil {
+		t.Errorf("expected an error if the managed cluster is not found")
+	}
+}
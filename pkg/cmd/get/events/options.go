@@ -0,0 +1,56 @@
+// Copyright Contributors to the Open Cluster Management project
+package events
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+	//Name of the managed cluster
+	Cluster string
+	//The kubeconfig of the managed cluster, the klusterlet events are skipped if empty
+	ManagedKubeconfigFile string
+	//The namespace of the klusterlet agents on the managed cluster
+	AgentNamespace string
+	//Only events of these types are shown if set
+	Types []string
+
+	Streams genericiooptions.IOStreams
+
+	printer *printer.PrinterOption
+
+	// sides records whether an event is collected from the hub or the managed cluster
+	sides map[types.UID]string
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+		printer:         printer.NewPrinterOption(pntOpt).WithDefaultFormat("table"),
+		sides:           map[types.UID]string{},
+	}
+}
+
+var pntOpt = printers.PrintOptions{
+	NoHeaders:     false,
+	WithNamespace: false,
+	WithKind:      false,
+	Wide:          false,
+	ShowLabels:    false,
+	Kind: schema.GroupKind{
+		Group: "",
+		Kind:  "Event",
+	},
+	ColumnLabels:     []string{},
+	SortBy:           "",
+	AllowMissingKeys: true,
+}
@@ -14,6 +14,12 @@ import (
 var example = `
 # Get placements.
 %[1]s get placements
+
+# Watch the clusters added to and removed from the decisions of a placement.
+%[1]s get placements test -n default --watch-decisions
+
+# Explain why a cluster is or is not selected by a placement.
+%[1]s get placements test -n default --explain cluster1
 `
 
 // NewCmd...
@@ -47,6 +53,10 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 
 	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "namespace to look up")
 
+	cmd.Flags().BoolVar(&o.WatchDecisions, "watch-decisions", false,
+		"Stream the clusters added to and removed from the decisions of the placement, with the reasons of its conditions")
+	cmd.Flags().StringVar(&o.Explain, "explain", "", "Explain why the cluster is or is not selected by the placement")
+
 	o.printer.AddFlag(cmd.Flags())
 
 	return cmd
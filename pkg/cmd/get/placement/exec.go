@@ -4,6 +4,10 @@ package placement
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -12,8 +16,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
 	clusterv1beta1 "open-cluster-management.io/api/client/cluster/clientset/versioned/typed/cluster/v1beta1"
 	"open-cluster-management.io/api/cluster/v1beta1"
+	placementhelpers "open-cluster-management.io/clusteradm/pkg/helpers/placement"
 	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
 )
 
@@ -38,6 +44,13 @@ func (o *Options) validate(args []string) (err error) {
 		o.PlacementName = args[0]
 	}
 
+	if o.WatchDecisions && len(o.Explain) > 0 {
+		return fmt.Errorf("--watch-decisions and --explain can not be used together")
+	}
+	if (o.WatchDecisions || len(o.Explain) > 0) && len(o.PlacementName) == 0 {
+		return fmt.Errorf("the placement name is required with --watch-decisions or --explain")
+	}
+
 	err = o.printer.Validate()
 	if err != nil {
 		return err
@@ -71,6 +84,23 @@ func (o *Options) run() (err error) {
 	}
 	o.Client = placementClient
 
+	if o.WatchDecisions || len(o.Explain) > 0 {
+		clusterClient, err := clusterclientset.NewForConfig(restConfig)
+		if err != nil {
+			return err
+		}
+		placement, err := o.getPlacement()
+		if err != nil {
+			return err
+		}
+		if o.WatchDecisions {
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			return o.watchDecisions(ctx, clusterClient, placement)
+		}
+		return o.explain(clusterClient, placement)
+	}
+
 	var placementList *v1beta1.PlacementList
 	if o.PlacementName == "" {
 		placementList, err = o.Client.Placements(o.Namespace).List(context.TODO(), metav1.ListOptions{})
@@ -88,6 +118,61 @@ func (o *Options) run() (err error) {
 	return o.printer.Print(o.Streams, placementList)
 }
 
+// getPlacement returns the placement with the name, which must be unique if the namespace is not set.
+func (o *Options) getPlacement() (*v1beta1.Placement, error) {
+	placementList, err := o.Client.Placements(o.Namespace).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", o.PlacementName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(placementList.Items) {
+	case 0:
+		return nil, fmt.Errorf("placement %s is not found", o.PlacementName)
+	case 1:
+		return &placementList.Items[0], nil
+	default:
+		return nil, fmt.Errorf("placement %s is found in %d namespaces, set the namespace with --namespace",
+			o.PlacementName, len(placementList.Items))
+	}
+}
+
+// explain prints the evaluation of the placement on the cluster step by step.
+func (o *Options) explain(clusterClient clusterclientset.Interface, placement *v1beta1.Placement) error {
+	input, err := placementhelpers.LoadInput(context.TODO(), clusterClient, placement)
+	if err != nil {
+		return err
+	}
+	explanation, err := placementhelpers.Explain(input, o.Explain)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range explanation.Warnings {
+		fmt.Fprintf(o.Streams.ErrOut, "Warning: %s\n", warning)
+	}
+	decided := "is not"
+	if explanation.Decided {
+		decided = "is"
+	}
+	selected := "would not be"
+	if explanation.Selected {
+		selected = "would be"
+	}
+	fmt.Fprintf(o.Streams.Out, "Cluster '%s' %s in the decisions of placement '%s' in '%s' namespace, and %s selected by evaluating the placement now.\n",
+		explanation.Cluster, decided, placement.Name, placement.Namespace, selected)
+	if explanation.Decided != explanation.Selected {
+		fmt.Fprintf(o.Streams.Out, "The decisions are not updated yet, or the placement is evaluated with CEL expressions, addon scores or spread constraints.\n")
+	}
+
+	w := tabwriter.NewWriter(o.Streams.Out, 4, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tPASSED\tMESSAGE")
+	for _, check := range explanation.Checks {
+		fmt.Fprintf(w, "%s\t%t\t%s\n", check.Name, check.Passed, check.Message)
+	}
+	return w.Flush()
+}
+
 func (o *Options) convertToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
 	decisionList, err := o.Client.PlacementDecisions(o.Namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
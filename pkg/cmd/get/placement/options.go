@@ -19,7 +19,11 @@ type Options struct {
 	PlacementName   string
 	Namespace       string
 	Output          string
-	printer         *printer.PrinterOption
+	// WatchDecisions streams the clusters added to and removed from the decisions of the placement
+	WatchDecisions bool
+	// Explain is the cluster to explain why it is or is not selected by the placement
+	Explain string
+	printer *printer.PrinterOption
 }
 
 func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
@@ -0,0 +1,169 @@
+// Copyright Contributors to the Open Cluster Management project
+package placement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	"open-cluster-management.io/api/cluster/v1beta1"
+)
+
+// decisionTracker tracks the clusters in the PlacementDecisions and the conditions of a placement.
+type decisionTracker struct {
+	// decisions are the clusters per PlacementDecision
+	decisions  map[string]sets.Set[string]
+	clusters   sets.Set[string]
+	conditions map[string]metav1.Condition
+}
+
+func newDecisionTracker() *decisionTracker {
+	return &decisionTracker{
+		decisions:  map[string]sets.Set[string]{},
+		clusters:   sets.New[string](),
+		conditions: map[string]metav1.Condition{},
+	}
+}
+
+// updateDecision updates the clusters of the PlacementDecision, and returns the clusters added to and
+// removed from the decisions of the placement. The decision is removed if deleted is true.
+func (t *decisionTracker) updateDecision(decision *v1beta1.PlacementDecision, deleted bool) (added, removed []string) {
+	if deleted {
+		delete(t.decisions, decision.Name)
+	} else {
+		clusters := sets.New[string]()
+		for _, d := range decision.Status.Decisions {
+			clusters.Insert(d.ClusterName)
+		}
+		t.decisions[decision.Name] = clusters
+	}
+
+	current := sets.New[string]()
+	for _, clusters := range t.decisions {
+		current = current.Union(clusters)
+	}
+	added = sets.List(current.Difference(t.clusters))
+	removed = sets.List(t.clusters.Difference(current))
+	t.clusters = current
+	return added, removed
+}
+
+// updateConditions returns the PlacementSatisfied and PlacementMisconfigured conditions of the placement
+// which are changed.
+func (t *decisionTracker) updateConditions(placement *v1beta1.Placement) []metav1.Condition {
+	var changed []metav1.Condition
+	for _, conditionType := range []string{v1beta1.PlacementConditionSatisfied, v1beta1.PlacementConditionMisconfigured} {
+		cond := meta.FindStatusCondition(placement.Status.Conditions, conditionType)
+		if cond == nil {
+			continue
+		}
+		last, ok := t.conditions[conditionType]
+		if ok && last.Status == cond.Status && last.Reason == cond.Reason && last.Message == cond.Message {
+			continue
+		}
+		t.conditions[conditionType] = *cond
+		changed = append(changed, *cond)
+	}
+	return changed
+}
+
+// watchDecisions prints the current decisions of the placement, then the clusters added and removed
+// with the changes of the conditions until the context is done.
+func (o *Options) watchDecisions(ctx context.Context, clusterClient clusterclientset.Interface, placement *v1beta1.Placement) error {
+	decisionSelector := fmt.Sprintf("%s=%s", v1beta1.PlacementLabel, placement.Name)
+	decisionList, err := clusterClient.ClusterV1beta1().PlacementDecisions(placement.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: decisionSelector,
+	})
+	if err != nil {
+		return err
+	}
+
+	tracker := newDecisionTracker()
+	for i := range decisionList.Items {
+		tracker.updateDecision(&decisionList.Items[i], false)
+	}
+	fmt.Fprintf(o.Streams.Out, "%s\tSELECTED\t%s\n", time.Now().Format(time.RFC3339), strings.Join(sets.List(tracker.clusters), ","))
+	o.printConditions(tracker.updateConditions(placement))
+
+	decisionWatcher, err := watchtools.NewRetryWatcherWithContext(ctx, decisionList.ResourceVersion, &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = decisionSelector
+			return clusterClient.ClusterV1beta1().PlacementDecisions(placement.Namespace).Watch(ctx, options)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer decisionWatcher.Stop()
+
+	placementWatcher, err := watchtools.NewRetryWatcherWithContext(ctx, placement.ResourceVersion, &cache.ListWatch{
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fmt.Sprintf("metadata.name=%s", placement.Name)
+			return clusterClient.ClusterV1beta1().Placements(placement.Namespace).Watch(ctx, options)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer placementWatcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-decisionWatcher.ResultChan():
+			if !ok {
+				return fmt.Errorf("the watch of the decisions of placement %s is closed", placement.Name)
+			}
+			if event.Type == watch.Error {
+				return fmt.Errorf("failed to watch the decisions of placement %s: %v", placement.Name, event.Object)
+			}
+			decision, ok := event.Object.(*v1beta1.PlacementDecision)
+			if !ok {
+				continue
+			}
+			added, removed := tracker.updateDecision(decision, event.Type == watch.Deleted)
+			o.printDecisionChanges(added, removed)
+		case event, ok := <-placementWatcher.ResultChan():
+			if !ok {
+				return fmt.Errorf("the watch of placement %s is closed", placement.Name)
+			}
+			switch event.Type {
+			case watch.Error:
+				return fmt.Errorf("failed to watch placement %s: %v", placement.Name, event.Object)
+			case watch.Deleted:
+				fmt.Fprintf(o.Streams.Out, "%s\tDELETED\tplacement %s\n", time.Now().Format(time.RFC3339), placement.Name)
+				return nil
+			}
+			if p, ok := event.Object.(*v1beta1.Placement); ok {
+				o.printConditions(tracker.updateConditions(p))
+			}
+		}
+	}
+}
+
+func (o *Options) printDecisionChanges(added, removed []string) {
+	now := time.Now().Format(time.RFC3339)
+	for _, cluster := range added {
+		fmt.Fprintf(o.Streams.Out, "%s\tADDED\t%s\n", now, cluster)
+	}
+	for _, cluster := range removed {
+		fmt.Fprintf(o.Streams.Out, "%s\tREMOVED\t%s\n", now, cluster)
+	}
+}
+
+func (o *Options) printConditions(conditions []metav1.Condition) {
+	now := time.Now().Format(time.RFC3339)
+	for _, cond := range conditions {
+		fmt.Fprintf(o.Streams.Out, "%s\t%s\t%s %s: %s\n", now, cond.Type, cond.Status, cond.Reason, cond.Message)
+	}
+}
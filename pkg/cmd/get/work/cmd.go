@@ -16,6 +16,12 @@ var example = `
 %[1]s get works --cluster cluster1
 # Get a specific manifestwork in a cluster
 %[1]s get works work1 --cluster cluster1
+# Get a specific manifestwork in all clusters
+%[1]s get works work1 --all-clusters
+# Get the status feedback of the resources of a manifestwork in clusters
+%[1]s get works work1 --clusters cluster1,cluster2 --feedback -o table
+# Get the resources not applied, not available or degraded in clusters
+%[1]s get works --clusters cluster1,cluster2 --failed-only -o table
 `
 
 // NewCmd...
@@ -47,6 +53,10 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 		},
 	}
 
+	cmd.Flags().BoolVar(&o.AllClusters, "all-clusters", false, "Get the manifestworks in all the managed clusters")
+	cmd.Flags().BoolVar(&o.Feedback, "feedback", false, "Show the status feedback values of the resources")
+	cmd.Flags().BoolVar(&o.FailedOnly, "failed-only", false,
+		"Show only the resources which are not applied, not available or degraded, with the messages")
 	o.printer.AddFlag(cmd.Flags())
 	o.ClusterOption.AddFlags(cmd.Flags())
 
@@ -14,6 +14,7 @@ import (
 	workclient "open-cluster-management.io/api/client/work/clientset/versioned"
 	workapiv1 "open-cluster-management.io/api/work/v1"
 	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
 )
 
 func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
@@ -38,9 +39,18 @@ func (o *Options) validate() (err error) {
 	if err := o.ClusterOption.Validate(); err != nil {
 		return err
 	}
+	if o.AllClusters && o.ClusterOption.AllClusters().Len() > 0 {
+		return fmt.Errorf("--all-clusters can not be specified with --cluster or --clusters")
+	}
+	if !o.AllClusters && o.ClusterOption.AllClusters().Len() == 0 {
+		return fmt.Errorf("either --cluster, --clusters or --all-clusters needs to be set")
+	}
 	if err := o.printer.Validate(); err != nil {
 		return err
 	}
+	if o.Feedback && o.FailedOnly {
+		return fmt.Errorf("--feedback and --failed-only can not be specified together")
+	}
 
 	return nil
 }
@@ -59,31 +69,70 @@ func (o *Options) run() (err error) {
 		return err
 	}
 
-	clusters := o.ClusterOption.AllClusters()
-
 	workList := &workapiv1.ManifestWorkList{Items: []workapiv1.ManifestWork{}}
-	for cluster := range clusters {
-		_, err = clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), cluster, metav1.GetOptions{})
+	if o.AllClusters {
+		// a single list of the works in the namespaces of all the clusters
+		works, err := o.listWorks(workClient, metav1.NamespaceAll)
 		if err != nil {
 			return err
 		}
+		workList.Items = works
+	}
 
-		listOpts := metav1.ListOptions{}
-		if len(o.workName) > 0 {
-			listOpts.FieldSelector = fmt.Sprintf("metadata.name=%s", o.workName)
+	for cluster := range o.ClusterOption.AllClusters() {
+		_, err = clusterClient.ClusterV1().ManagedClusters().Get(context.TODO(), cluster, metav1.GetOptions{})
+		if err != nil {
+			return err
 		}
-		works, err := workClient.WorkV1().ManifestWorks(cluster).List(context.TODO(), listOpts)
+
+		works, err := o.listWorks(workClient, cluster)
 		if err != nil {
 			return err
 		}
-		workList.Items = append(workList.Items, works.Items...)
+		workList.Items = append(workList.Items, works...)
 	}
 
-	o.printer.WithTreeConverter(o.convertToTree).WithTableConverter(o.converToTable)
+	// the shards of a work split by its size are shown as the work
+	workList.Items = workhelpers.MergeShards(workList.Items)
+
+	switch {
+	case o.Feedback:
+		o.printer.WithTreeConverter(convertFeedbacksToTree).WithTableConverter(convertFeedbacksToTable)
+	case o.FailedOnly:
+		workList = failedWorks(workList)
+		o.printer.WithTreeConverter(convertFailuresToTree).WithTableConverter(convertFailuresToTable)
+	default:
+		o.printer.WithTreeConverter(o.convertToTree).WithTableConverter(o.converToTable)
+	}
 
 	return o.printer.Print(o.Streams, workList)
 }
 
+// listWorks lists the works in the namespace, with the shards of the work if the name is specified.
+func (o *Options) listWorks(workClient workclient.Interface, namespace string) ([]workapiv1.ManifestWork, error) {
+	if len(o.workName) == 0 {
+		works, err := workClient.WorkV1().ManifestWorks(namespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return works.Items, nil
+	}
+
+	works, err := workClient.WorkV1().ManifestWorks(namespace).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", o.workName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	shards, err := workClient.WorkV1().ManifestWorks(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: workhelpers.ShardSelector(o.workName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(works.Items, shards.Items...), nil
+}
+
 func (o *Options) convertToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
 	if workList, ok := obj.(*workapiv1.ManifestWorkList); ok {
 		for _, work := range workList.Items {
@@ -21,6 +21,15 @@ type Options struct {
 	Streams genericiooptions.IOStreams
 
 	printer *printer.PrinterOption
+
+	// AllClusters gets the works in all the clusters with a single list
+	AllClusters bool
+
+	// Feedback prints the status feedback values of the resources instead
+	Feedback bool
+
+	// FailedOnly prints only the resources which are not applied, not available or degraded, with the messages
+	FailedOnly bool
 }
 
 func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
@@ -28,7 +37,7 @@ func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, st
 		ClusteradmFlags: clusteradmFlags,
 		Streams:         streams,
 		printer:         printer.NewPrinterOption(pntOpt),
-		ClusterOption:   genericclioptionsclusteradm.NewClusterOption(),
+		ClusterOption:   genericclioptionsclusteradm.NewClusterOption().AllowUnset(),
 	}
 }
 
@@ -0,0 +1,117 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
+)
+
+// failedWorks returns the works with resources which are not applied, not available or degraded.
+func failedWorks(workList *workapiv1.ManifestWorkList) *workapiv1.ManifestWorkList {
+	failed := &workapiv1.ManifestWorkList{Items: []workapiv1.ManifestWork{}}
+	for i := range workList.Items {
+		if len(workhelpers.ManifestFailures(&workList.Items[i])) > 0 {
+			failed.Items = append(failed.Items, workList.Items[i])
+		}
+	}
+	return failed
+}
+
+// resourceKey returns the key of the resource in the tree, in the same format as printer.WorkDetails.
+func resourceKey(resourceMeta workapiv1.ManifestResourceMeta) string {
+	identifier := resourceMeta.Name
+	if len(resourceMeta.Namespace) > 0 {
+		identifier = resourceMeta.Namespace + "/" + identifier
+	}
+	return fmt.Sprintf("%s.%s", resourceMeta.Resource, identifier)
+}
+
+func convertFeedbacksToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
+	if workList, ok := obj.(*workapiv1.ManifestWorkList); ok {
+		for i := range workList.Items {
+			work := &workList.Items[i]
+			mp := map[string]interface{}{}
+			for _, feedback := range workhelpers.Feedbacks(work) {
+				mp[fmt.Sprintf(".Feedback.%s.%s", resourceKey(feedback.ResourceMeta), feedback.Name)] = feedback.Value
+			}
+			tree.AddFileds(fmt.Sprintf("%s.%s", work.Namespace, work.Name), &mp)
+		}
+	}
+	return tree
+}
+
+func convertFeedbacksToTable(obj runtime.Object) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Cluster", Type: "string"},
+			{Name: "Resource", Type: "string"},
+			{Name: "Feedback", Type: "string"},
+			{Name: "Value", Type: "string"},
+		},
+		Rows: []metav1.TableRow{},
+	}
+
+	if workList, ok := obj.(*workapiv1.ManifestWorkList); ok {
+		for i := range workList.Items {
+			work := &workList.Items[i]
+			for _, feedback := range workhelpers.Feedbacks(work) {
+				table.Rows = append(table.Rows, metav1.TableRow{
+					Cells:  []interface{}{work.Name, work.Namespace, feedback.Resource, feedback.Name, feedback.Value},
+					Object: runtime.RawExtension{Object: work},
+				})
+			}
+		}
+	}
+
+	return table
+}
+
+func convertFailuresToTree(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
+	if workList, ok := obj.(*workapiv1.ManifestWorkList); ok {
+		for i := range workList.Items {
+			work := &workList.Items[i]
+			mp := map[string]interface{}{}
+			for _, failure := range workhelpers.ManifestFailures(work) {
+				mp[fmt.Sprintf(".Failures.%s.%s", resourceKey(failure.ResourceMeta), failure.Condition)] =
+					fmt.Sprintf("%s: %s", failure.Reason, failure.Message)
+			}
+			tree.AddFileds(fmt.Sprintf("%s.%s", work.Namespace, work.Name), &mp)
+		}
+	}
+	return tree
+}
+
+func convertFailuresToTable(obj runtime.Object) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Cluster", Type: "string"},
+			{Name: "Resource", Type: "string"},
+			{Name: "Condition", Type: "string"},
+			{Name: "Reason", Type: "string"},
+			{Name: "Message", Type: "string"},
+		},
+		Rows: []metav1.TableRow{},
+	}
+
+	if workList, ok := obj.(*workapiv1.ManifestWorkList); ok {
+		for i := range workList.Items {
+			work := &workList.Items[i]
+			for _, failure := range workhelpers.ManifestFailures(work) {
+				table.Rows = append(table.Rows, metav1.TableRow{
+					Cells:  []interface{}{work.Name, work.Namespace, failure.Resource, failure.Condition, failure.Reason, failure.Message},
+					Object: runtime.RawExtension{Object: work},
+				})
+			}
+		}
+	}
+
+	return table
+}
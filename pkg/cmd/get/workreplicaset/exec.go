@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+package workreplicaset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
+	workapiv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
+	if len(args) > 1 {
+		return fmt.Errorf("can only specify one manifestworkreplicaset")
+	}
+	if len(args) == 1 {
+		o.name = args[0]
+	}
+
+	o.printer.Competele()
+
+	return nil
+}
+
+func (o *Options) validate() error {
+	if err := o.ClusteradmFlags.ValidateHub(); err != nil {
+		return err
+	}
+	return o.printer.Validate()
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	o.workClient, err = workclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	listOpts := metav1.ListOptions{}
+	if len(o.name) > 0 {
+		listOpts.FieldSelector = fmt.Sprintf("metadata.name=%s", o.name)
+	}
+	workSets, err := o.workClient.WorkV1alpha1().ManifestWorkReplicaSets(o.Namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return err
+	}
+	if len(o.name) > 0 && len(workSets.Items) == 0 {
+		return fmt.Errorf("manifestworkreplicaset %s is not found", o.name)
+	}
+
+	states := map[string][]clusterState{}
+	for _, workSet := range workSets.Items {
+		states[workSet.Namespace+"/"+workSet.Name], err = o.clusterStates(&workSet)
+		if err != nil {
+			return err
+		}
+	}
+
+	o.printer.WithTreeConverter(func(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
+		return convertToTree(obj, tree, states)
+	}).WithTableConverter(func(obj runtime.Object) *metav1.Table {
+		return convertToTable(obj, states)
+	})
+
+	return o.printer.Print(o.Streams, workSets)
+}
+
+// clusterState is the rollout state of the ManifestWork of a ManifestWorkReplicaSet on a cluster.
+type clusterState struct {
+	cluster string
+	state   string
+}
+
+func (o *Options) clusterStates(workSet *workapiv1alpha1.ManifestWorkReplicaSet) ([]clusterState, error) {
+	works, err := workhelpers.ReplicaSetWorks(context.TODO(), o.workClient, workSet.Namespace, workSet.Name)
+	if err != nil {
+		return nil, err
+	}
+	var states []clusterState
+	for i := range works {
+		states = append(states, clusterState{cluster: works[i].Namespace, state: workhelpers.RolloutState(&works[i])})
+	}
+	return states, nil
+}
+
+func convertToTree(obj runtime.Object, tree *printer.TreePrinter, states map[string][]clusterState) *printer.TreePrinter {
+	if workSetList, ok := obj.(*workapiv1alpha1.ManifestWorkReplicaSetList); ok {
+		for _, workSet := range workSetList.Items {
+			summary := workSet.Status.Summary
+			mp := map[string]interface{}{
+				".Namespace":           workSet.Namespace,
+				".RolledOut":           rolledOut(&workSet),
+				".Summary.Total":       summary.Total,
+				".Summary.Applied":     summary.Applied,
+				".Summary.Available":   summary.Available,
+				".Summary.Degraded":    summary.Degraded,
+				".Summary.Progressing": summary.Progressing,
+			}
+			for _, placement := range workSet.Status.PlacementsSummary {
+				mp[fmt.Sprintf(".Placements.%s.AvailableDecisionGroups", placement.Name)] = placement.AvailableDecisionGroups
+				mp[fmt.Sprintf(".Placements.%s.Total", placement.Name)] = placement.Summary.Total
+			}
+			for _, placementRef := range workSet.Spec.PlacementRefs {
+				mp[fmt.Sprintf(".Placements.%s.RolloutStrategy", placementRef.Name)] = string(placementRef.RolloutStrategy.Type)
+			}
+			for _, state := range states[workSet.Namespace+"/"+workSet.Name] {
+				mp[".Clusters."+state.cluster] = state.state
+			}
+			tree.AddFileds(workSet.Name, &mp)
+		}
+	}
+	return tree
+}
+
+func convertToTable(obj runtime.Object, states map[string][]clusterState) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string"},
+			{Name: "Namespace", Type: "string"},
+			{Name: "Placements", Type: "string"},
+			{Name: "Total", Type: "integer"},
+			{Name: "Applied", Type: "integer"},
+			{Name: "Available", Type: "integer"},
+			{Name: "Degraded", Type: "integer"},
+			{Name: "Progressing", Type: "integer"},
+			{Name: "Rolled Out", Type: "string"},
+			{Name: "Clusters", Type: "string"},
+		},
+		Rows: []metav1.TableRow{},
+	}
+
+	if workSetList, ok := obj.(*workapiv1alpha1.ManifestWorkReplicaSetList); ok {
+		for _, workSet := range workSetList.Items {
+			var placements, clusters []string
+			for _, placementRef := range workSet.Spec.PlacementRefs {
+				placements = append(placements, placementRef.Name)
+			}
+			for _, state := range states[workSet.Namespace+"/"+workSet.Name] {
+				clusters = append(clusters, fmt.Sprintf("%s(%s)", state.cluster, state.state))
+			}
+			summary := workSet.Status.Summary
+			table.Rows = append(table.Rows, metav1.TableRow{
+				Cells: []interface{}{workSet.Name, workSet.Namespace, strings.Join(placements, ","),
+					summary.Total, summary.Applied, summary.Available, summary.Degraded, summary.Progressing,
+					rolledOut(&workSet), strings.Join(clusters, ",")},
+				Object: runtime.RawExtension{Object: &workSet},
+			})
+		}
+	}
+
+	return table
+}
+
+// rolledOut returns the status and reason of the PlacementRolledOut condition, Unknown if it is not reported yet.
+func rolledOut(workSet *workapiv1alpha1.ManifestWorkReplicaSet) string {
+	cond := meta.FindStatusCondition(workSet.Status.Conditions, workapiv1alpha1.ManifestWorkReplicaSetConditionPlacementRolledOut)
+	if cond == nil {
+		return string(metav1.ConditionUnknown)
+	}
+	return fmt.Sprintf("%s(%s)", cond.Status, cond.Reason)
+}
@@ -0,0 +1,74 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var labelExample = `
+# Add a label to clusters
+%[1]s label cluster cluster1 cluster2 env=prod
+
+# Update the label of all the clusters in a clusterset
+%[1]s label cluster --clusterset set1 env=staging --overwrite
+
+# Remove a label from all the clusters
+%[1]s label cluster --all env-
+`
+
+var annotateExample = `
+# Add an annotation to clusters
+%[1]s annotate cluster cluster1 cluster2 owner=team-a
+
+# Remove an annotation from all the clusters in a clusterset
+%[1]s annotate cluster --clusterset set1 owner-
+`
+
+// NewCmd returns the command to update the labels or annotations of clusters by the field.
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams, field string) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams, field)
+
+	cmd := &cobra.Command{
+		Use:   "cluster [NAME...] KEY_1=VAL_1 ... KEY_N=VAL_N",
+		Short: fmt.Sprintf("update the %s of managed clusters", field),
+		Long: fmt.Sprintf("set or remove the %s of managed clusters, a key is removed with a trailing dash. "+
+			"The %s in the open-cluster-management.io domain are maintained by OCM and only changed with --force", field, field),
+		Example:      fmt.Sprintf(labelExample, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+	if field == clusterhelpers.FieldAnnotations {
+		cmd.Example = fmt.Sprintf(annotateExample, clusteradmhelpers.GetExampleHeader())
+	}
+
+	cmd.Flags().BoolVar(&o.All, "all", false, "Update all the managed clusters")
+	cmd.Flags().StringVar(&o.ClusterSet, "clusterset", "", "Update all the managed clusters in the clusterset")
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", false, "If set, the value of an existing key is overwritten")
+	cmd.Flags().BoolVar(&o.Force, "force", false, "If set, the keys reserved by OCM are allowed to be changed")
+
+	return cmd
+}
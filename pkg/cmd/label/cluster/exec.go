@@ -0,0 +1,80 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
+	var specs []string
+	o.Clusters, specs = clusterhelpers.SplitArgs(args)
+	if len(specs) == 0 {
+		return fmt.Errorf("at least one {key}={value} or {key}- must be specified")
+	}
+	o.set, o.remove, err = clusterhelpers.ParseKeyValues(specs, o.field == clusterhelpers.FieldLabels)
+	return err
+}
+
+func (o *Options) validate() error {
+	if err := o.ClusteradmFlags.ValidateHub(); err != nil {
+		return err
+	}
+
+	selectors := 0
+	for _, selected := range []bool{len(o.Clusters) > 0, o.All, len(o.ClusterSet) > 0} {
+		if selected {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return fmt.Errorf("exactly one of the cluster names, --clusterset or --all must be specified")
+	}
+
+	if !o.Force {
+		return clusterhelpers.CheckReservedKeys(o.set, o.remove, clusterhelpers.IsReservedKey)
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	clusters, err := clusterhelpers.SelectClusters(ctx, clusterClient, o.Clusters, o.All, o.ClusterSet)
+	if err != nil {
+		return err
+	}
+	if len(clusters) == 0 {
+		fmt.Fprintf(o.Streams.Out, "No clusters found\n")
+		return nil
+	}
+
+	var errs []error
+	for _, cluster := range clusters {
+		changed, err := clusterhelpers.PatchMetadata(ctx, clusterClient, cluster, o.field, o.set, o.remove, o.Overwrite, o.ClusteradmFlags.DryRun)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if changed {
+			fmt.Fprintf(o.Streams.Out, "Cluster %s %s updated\n", cluster, o.field)
+		} else {
+			fmt.Fprintf(o.Streams.Out, "Cluster %s %s not changed\n", cluster, o.field)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
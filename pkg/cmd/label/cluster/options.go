@@ -0,0 +1,40 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	Streams genericiooptions.IOStreams
+
+	Clusters []string
+
+	// All and ClusterSet select the clusters if no cluster name is specified
+	All        bool
+	ClusterSet string
+
+	Overwrite bool
+
+	// Force allows to change the keys reserved by OCM
+	Force bool
+
+	// field is the labels or annotations
+	field string
+
+	set    map[string]string
+	remove []string
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams, field string) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+		field:           field,
+	}
+}
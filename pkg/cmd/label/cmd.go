@@ -0,0 +1,35 @@
+// Copyright Contributors to the Open Cluster Management project
+package label
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/cmd/label/cluster"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+)
+
+// NewCmd provides a cobra command wrapping the label subcommands
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "label",
+		Short: "update the labels of managed clusters",
+	}
+
+	cmd.AddCommand(cluster.NewCmd(clusteradmFlags, streams, clusterhelpers.FieldLabels))
+
+	return cmd
+}
+
+// NewAnnotateCmd provides a cobra command wrapping the annotate subcommands
+func NewAnnotateCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "annotate",
+		Short: "update the annotations of managed clusters",
+	}
+
+	cmd.AddCommand(cluster.NewCmd(clusteradmFlags, streams, clusterhelpers.FieldAnnotations))
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+// Copyright Contributors to the Open Cluster Management project
+package logs
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	"open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var example = `
+# Print the logs of the registration agent of cluster1 through cluster-proxy
+%[1]s logs --cluster cluster1 --component registration --sa test
+# Follow the logs of the work agent of cluster1 in the last hour
+%[1]s logs --cluster cluster1 --component work --sa test --follow --since 1h
+# Print the logs of the previous instance of the cluster-proxy addon agent
+%[1]s logs --cluster cluster1 --component cluster-proxy --sa test --previous
+`
+
+// NewCmd ...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "print the logs of an agent on a managed cluster",
+		Long: "print the logs of the klusterlet agents or an addon agent on a managed cluster through the cluster-proxy addon. " +
+			"The component can be registration, work, agent (the klusterlet agent in singleton mode) or the name of an addon. " +
+			"(Only supports managed service account token as certificate.)",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRun: func(c *cobra.Command, args []string) {
+			helpers.DryRunMessage(o.ClusteradmFlags.DryRun)
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(c.Context()); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Cluster, "cluster", "c", "", "Name of the managed cluster")
+	cmd.Flags().StringVar(&o.Component, "component", "", "The component to print the logs of, can be registration, work, agent or the name of an addon")
+	cmd.Flags().StringVar(&o.ManagedServiceAccount, "sa", "", "The name of the managedServiceAccount")
+	cmd.Flags().StringVar(&o.Container, "container", "", "The container to print the logs of, the first container of the pod if not set")
+	cmd.Flags().StringVarP(&o.Selector, "selector", "l", "", "The label selector of the addon agent pods, only used when the component is an addon")
+	cmd.Flags().BoolVarP(&o.Follow, "follow", "f", false, "If set, the logs are streamed")
+	cmd.Flags().DurationVar(&o.Since, "since", 0, "Only print the logs newer than a relative duration like 5s, 2m, or 3h")
+	cmd.Flags().BoolVarP(&o.Previous, "previous", "p", false, "If set, print the logs of the previous instance of the container")
+
+	return cmd
+}
@@ -0,0 +1,251 @@
+// Copyright Contributors to the Open Cluster Management project
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	addonclient "open-cluster-management.io/api/client/addon/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/client/cluster/clientset/versioned/typed/cluster/v1"
+	operatorclient "open-cluster-management.io/api/client/operator/clientset/versioned"
+	"open-cluster-management.io/clusteradm/pkg/config"
+	"open-cluster-management.io/clusteradm/pkg/helpers/clusterproxy"
+)
+
+const (
+	componentRegistration = "registration"
+	componentWork         = "work"
+	componentAgent        = "agent"
+)
+
+// klusterletDeploymentSuffixes maps the klusterlet components to the suffix of their deployment names, the
+// deployments are named after the klusterlet, e.g. klusterlet-registration-agent.
+var klusterletDeploymentSuffixes = map[string]string{
+	componentRegistration: "-registration-agent",
+	componentWork:         "-work-agent",
+	componentAgent:        "-agent",
+}
+
+func (o *Options) complete(_ *cobra.Command, _ []string) error {
+	// accept the full agent names as aliases, e.g. registration-agent or klusterlet-agent
+	switch o.Component {
+	case "registration-agent", "klusterlet-registration-agent":
+		o.Component = componentRegistration
+	case "work-agent", "klusterlet-work-agent":
+		o.Component = componentWork
+	case "klusterlet-agent":
+		o.Component = componentAgent
+	}
+	klog.V(1).InfoS("logs options:", "cluster", o.Cluster, "component", o.Component)
+	return nil
+}
+
+func (o *Options) validate() error {
+	if err := o.ClusteradmFlags.ValidateHub(); err != nil {
+		return err
+	}
+	if len(o.Cluster) == 0 {
+		return fmt.Errorf("--cluster must be set")
+	}
+	if len(o.Component) == 0 {
+		return fmt.Errorf("--component must be set")
+	}
+	if len(o.ManagedServiceAccount) == 0 {
+		return fmt.Errorf("--sa must be set")
+	}
+	if o.Since < 0 {
+		return fmt.Errorf("--since must be a positive duration")
+	}
+	return nil
+}
+
+func (o *Options) run(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	hubRestConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return errors.Wrapf(err, "failed loading hub cluster's client config")
+	}
+
+	proxyConfig, err := clusterproxy.GetProxyConfig(hubRestConfig, o.Streams)
+	if err != nil {
+		return err
+	}
+	if proxyConfig == nil {
+		return nil
+	}
+
+	clusterClient, err := clusterv1.NewForConfig(hubRestConfig)
+	if err != nil {
+		return err
+	}
+	_, err = clusterClient.ManagedClusters().Get(ctx, o.Cluster, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	token, err := clusterproxy.GetManagedServiceAccountToken(hubRestConfig, o.ManagedServiceAccount, o.Cluster)
+	if err != nil {
+		return err
+	}
+
+	proxyCertificates, err := clusterproxy.GetProxyCertificates(hubRestConfig, proxyConfig)
+	if err != nil {
+		return err
+	}
+
+	// listen on a free local port, so the command is able to run concurrently
+	proxyServerPort, portForwardClose, err := clusterproxy.ListenLocalProxy(ctx, hubRestConfig, proxyConfig, 0)
+	if err != nil {
+		return err
+	}
+	defer portForwardClose()
+
+	managedRestConfig, err := clusterproxy.NewClusterRESTConfig(ctx, o.Cluster, proxyServerPort, proxyCertificates, token)
+	if err != nil {
+		return err
+	}
+	managedKubeClient, err := kubernetes.NewForConfig(managedRestConfig)
+	if err != nil {
+		return err
+	}
+
+	namespace, selector, err := o.resolvePodSelector(ctx, hubRestConfig, managedRestConfig, managedKubeClient)
+	if err != nil {
+		return err
+	}
+
+	pod, err := selectPod(ctx, managedKubeClient, namespace, selector)
+	if err != nil {
+		return err
+	}
+	klog.V(1).InfoS("printing logs of pod", "namespace", pod.Namespace, "name", pod.Name)
+
+	logOptions := &corev1.PodLogOptions{
+		Container: o.Container,
+		Follow:    o.Follow,
+		Previous:  o.Previous,
+	}
+	if o.Since > 0 {
+		logOptions.SinceSeconds = ptr.To[int64](int64(o.Since.Seconds()))
+	}
+	stream, err := managedKubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed getting logs of pod %s/%s", pod.Namespace, pod.Name)
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(o.Streams.Out, stream)
+	return err
+}
+
+// resolvePodSelector returns the namespace and label selector of the pods of the component. The pods
+// of the klusterlet agents are resolved from the deployments in the related resources of the Klusterlet,
+// the pods of an addon agent are found in the install namespace of the ManagedClusterAddOn.
+func (o *Options) resolvePodSelector(
+	ctx context.Context,
+	hubRestConfig, managedRestConfig *rest.Config,
+	managedKubeClient kubernetes.Interface) (string, string, error) {
+	if suffix, ok := klusterletDeploymentSuffixes[o.Component]; ok {
+		operatorClient, err := operatorclient.NewForConfig(managedRestConfig)
+		if err != nil {
+			return "", "", err
+		}
+		klusterlet, err := operatorClient.OperatorV1().Klusterlets().Get(ctx, config.KlusterletName, metav1.GetOptions{})
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed getting klusterlet on cluster %s", o.Cluster)
+		}
+		for _, resource := range klusterlet.Status.RelatedResources {
+			if resource.Resource != "deployments" || !strings.HasSuffix(resource.Name, suffix) {
+				continue
+			}
+			// the registration and work agent deployments also end with -agent
+			if o.Component == componentAgent && resource.Name != klusterlet.Name+suffix {
+				continue
+			}
+			return deploymentPodSelector(ctx, managedKubeClient, resource.Namespace, resource.Name)
+		}
+		return "", "", fmt.Errorf("the %s agent is not found in the related resources of the klusterlet on cluster %s", o.Component, o.Cluster)
+	}
+
+	addonClient, err := addonclient.NewForConfig(hubRestConfig)
+	if err != nil {
+		return "", "", err
+	}
+	addon, err := addonClient.AddonV1alpha1().ManagedClusterAddOns(o.Cluster).Get(ctx, o.Component, metav1.GetOptions{})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed getting addon %s on cluster %s", o.Component, o.Cluster)
+	}
+	namespace := addon.Status.Namespace
+	if len(namespace) == 0 {
+		namespace = addon.Spec.InstallNamespace
+	}
+	if len(namespace) == 0 {
+		return "", "", fmt.Errorf("the install namespace of addon %s on cluster %s is unknown", o.Component, o.Cluster)
+	}
+	if len(o.Selector) > 0 {
+		return namespace, o.Selector, nil
+	}
+
+	// the addon agent deployments are usually named after the addon
+	deploys, err := managedKubeClient.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	for _, deploy := range deploys.Items {
+		if strings.Contains(deploy.Name, o.Component) {
+			return deploymentPodSelector(ctx, managedKubeClient, deploy.Namespace, deploy.Name)
+		}
+	}
+	return "", "", fmt.Errorf("no deployment of addon %s is found in namespace %s on cluster %s, use --selector to specify the agent pods",
+		o.Component, namespace, o.Cluster)
+}
+
+func deploymentPodSelector(ctx context.Context, kubeClient kubernetes.Interface, namespace, name string) (string, string, error) {
+	deploy, err := kubeClient.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deploy.Spec.Selector)
+	if err != nil {
+		return "", "", err
+	}
+	return namespace, selector.String(), nil
+}
+
+// selectPod returns the newest running pod matching the selector, or the newest pod if none is running.
+func selectPod(ctx context.Context, kubeClient kubernetes.Interface, namespace, selector string) (*corev1.Pod, error) {
+	pods, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no pod is found in namespace %s with selector %q", namespace, selector)
+	}
+
+	sort.SliceStable(pods.Items, func(i, j int) bool {
+		iRunning := pods.Items[i].Status.Phase == corev1.PodRunning
+		jRunning := pods.Items[j].Status.Phase == corev1.PodRunning
+		if iRunning != jRunning {
+			return iRunning
+		}
+		return pods.Items[j].CreationTimestamp.Before(&pods.Items[i].CreationTimestamp)
+	})
+	return &pods.Items[0], nil
+}
@@ -0,0 +1,75 @@
+// Copyright Contributors to the Open Cluster Management project
+package logs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekube "k8s.io/client-go/kubernetes/fake"
+)
+
+func newPod(name string, phase corev1.PodPhase, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "open-cluster-management-agent",
+			Labels:            map[string]string{"app": "klusterlet-registration-agent"},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestSelectPod(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name        string
+		pods        []runtime.Object
+		expectedPod string
+		expectErr   bool
+	}{
+		{
+			name:      "no pod",
+			expectErr: true,
+		},
+		{
+			name: "prefer running pod",
+			pods: []runtime.Object{
+				newPod("pending", corev1.PodPending, now),
+				newPod("running", corev1.PodRunning, now.Add(-time.Hour)),
+			},
+			expectedPod: "running",
+		},
+		{
+			name: "prefer newest pod",
+			pods: []runtime.Object{
+				newPod("old", corev1.PodRunning, now.Add(-time.Hour)),
+				newPod("new", corev1.PodRunning, now),
+			},
+			expectedPod: "new",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kubeClient := fakekube.NewSimpleClientset(c.pods...)
+			pod, err := selectPod(context.TODO(), kubeClient, "open-cluster-management-agent", "app=klusterlet-registration-agent")
+			if c.expectErr {
+				if err == nil {
+					t.Errorf("expect error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pod.Name != c.expectedPod {
+				t.Errorf("expect pod %s but got %s", c.expectedPod, pod.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Contributors to the Open Cluster Management project
+package logs
+
+import (
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+	//Name of the managed cluster
+	Cluster string
+	//The component to print the logs of: registration, work, agent or an addon name
+	Component string
+	//The name of the managedServiceAccount used to access the managed cluster
+	ManagedServiceAccount string
+	//The container of the pod, the first container if empty
+	Container string
+	//The label selector of the addon agent pods
+	Selector string
+	//If true, stream the logs
+	Follow bool
+	//Only print the logs newer than the duration
+	Since time.Duration
+	//If true, print the logs of the previous instance of the container
+	Previous bool
+
+	Streams genericiooptions.IOStreams
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+	}
+}
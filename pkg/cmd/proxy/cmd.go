@@ -7,6 +7,7 @@ import (
 
 	"open-cluster-management.io/clusteradm/pkg/cmd/proxy/health"
 	"open-cluster-management.io/clusteradm/pkg/cmd/proxy/kubectl"
+	"open-cluster-management.io/clusteradm/pkg/cmd/proxy/serve"
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
 )
 
@@ -20,6 +21,7 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 
 	cmd.AddCommand(health.NewCmd(clusteradmFlags, streams))
 	cmd.AddCommand(kubectl.NewCmd(clusteradmFlags, streams))
+	cmd.AddCommand(serve.NewCmd(clusteradmFlags, streams))
 
 	return cmd
 }
@@ -14,6 +14,9 @@ import (
 var example = `
 # Probing healthiness of each managed clusters through the konnectivity tunnels installed by cluster-proxy addon
 %[1]s proxy health
+
+# Probing the managed clusters every minute in parallel, and exposing the results as prometheus metrics
+%[1]s proxy health --interval 1m --metrics-addr :9100 -o json
 `
 
 const (
@@ -59,6 +62,11 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 		"Konnectivity proxy server's entry hostname")
 	cmd.Flags().IntVar(&o.proxyServerPort, "proxy-server-port", 8090,
 		"Konnectivity proxy server's entry port")
+	cmd.Flags().DurationVar(&o.interval, "interval", 0,
+		"If set, probe the managed clusters continuously with this interval until interrupted")
+	cmd.Flags().StringVar(&o.metricsAddr, "metrics-addr", "",
+		"If set, expose the probing results as prometheus metrics on /metrics of this address, requires --interval")
+	cmd.Flags().StringVarP(&o.output, "output", "o", outputTable, "Output format, one of table and json")
 	o.ClusterOption.AddFlags(cmd.Flags())
 
 	return cmd
@@ -5,14 +5,20 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"text/tabwriter"
 	"time"
 
@@ -41,6 +47,18 @@ import (
 	"open-cluster-management.io/clusteradm/pkg/config"
 )
 
+const (
+	healthTrue    = "True"
+	healthFalse   = "False"
+	healthUnknown = "Unknown"
+
+	outputTable = "table"
+	outputJSON  = "json"
+
+	// probeTimeout is the timeout of probing a single managed cluster
+	probeTimeout = 30 * time.Second
+)
+
 func (o *Options) complete(_ *cobra.Command, _ []string) error {
 	if len(o.proxyClientCACertPath) > 0 && len(o.proxyClientCertPath) > 0 && len(o.proxyClientKeyPath) > 0 {
 		o.isProxyServerAddressProvided = true
@@ -66,6 +84,15 @@ func (o *Options) validate() error {
 	if err := o.ClusterOption.Validate(); err != nil {
 		return err
 	}
+	if o.output != outputTable && o.output != outputJSON {
+		return fmt.Errorf("invalid output format %q, supported formats are %s and %s", o.output, outputTable, outputJSON)
+	}
+	if o.interval < 0 {
+		return errors.New("--interval must not be negative")
+	}
+	if len(o.metricsAddr) > 0 && o.interval == 0 {
+		return errors.New("--interval must be set to expose the metrics")
+	}
 	return nil
 }
 
@@ -116,12 +143,6 @@ func (o *Options) run(streams genericiooptions.IOStreams) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed initializing cluster client")
 	}
-	managedClusterList, err := clusterClient.ManagedClusters().List(
-		context.TODO(),
-		metav1.ListOptions{})
-	if err != nil {
-		return errors.Wrapf(err, "failed listing managed clusters")
-	}
 
 	ctx, cancelFn := context.WithCancel(context.Background())
 	defer cancelFn()
@@ -149,30 +170,171 @@ func (o *Options) run(streams genericiooptions.IOStreams) error {
 		return errors.Wrapf(err, "failed building tls config")
 	}
 
-	probingClusters := o.ClusterOption.AllClusters()
-	w := newWriter(streams)
-	for _, cluster := range managedClusterList.Items {
-		if probingClusters.Len() == 0 || probingClusters.Has(cluster.Name) {
-			tunnel, err := konnectivity.CreateSingleUseGrpcTunnelWithContext(
-				context.TODO(),
-				ctx,
-				net.JoinHostPort(o.proxyServerHost, strconv.Itoa(o.proxyServerPort)),
-				grpc.WithTransportCredentials(grpccredentials.NewTLS(tlsCfg)),
-			)
-			if err != nil {
-				return errors.Wrapf(err, "failed starting konnectivity proxy")
-			}
+	var metrics *probeMetrics
+	if len(o.metricsAddr) > 0 {
+		metrics = newProbeMetrics()
+		if err := metrics.serve(ctx, o.metricsAddr); err != nil {
+			return err
+		}
+	}
+
+	p := &prober{
+		options:       o,
+		hubRestConfig: hubRestConfig,
+		clusterClient: clusterClient,
+		addonClient:   addonClient,
+		tlsCfg:        tlsCfg,
+	}
+	p.probe = p.probeTunnel
+	if o.interval == 0 {
+		results, err := p.probeAll(ctx)
+		if err != nil {
+			return err
+		}
+		return o.print(streams, results)
+	}
 
-			if err := o.visit(&w, hubRestConfig, addonClient, tunnel.DialContext, cluster.Name); err != nil {
-				klog.Errorf("An error occurred when requesting: %v", err)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	for {
+		results, err := p.probeAll(ctx)
+		if err != nil {
+			klog.Errorf("Failed probing the managed clusters: %v", err)
+		} else {
+			if metrics != nil {
+				metrics.record(results)
 			}
+			if err := o.print(streams, results); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(o.interval):
+		}
+	}
+}
+
+// probeResult is the healthiness of cluster-proxy on a managed cluster
+type probeResult struct {
+	Cluster   string `json:"cluster"`
+	Installed bool   `json:"installed"`
+	Available bool   `json:"available"`
+	// Health is True if the /healthz endpoint of the managed cluster responds ok through the tunnel, Unknown
+	// if the request fails, and False otherwise.
+	Health         string   `json:"health"`
+	LatencySeconds *float64 `json:"latencySeconds,omitempty"`
+	TimedOut       bool     `json:"timedOut,omitempty"`
+}
+
+// prober probes the managed clusters through the konnectivity tunnels of the proxy-server
+type prober struct {
+	options       *Options
+	hubRestConfig *rest.Config
+	clusterClient clusterv1.ClusterV1Interface
+	addonClient   addonv1alpha1client.Interface
+	tlsCfg        *tls.Config
+
+	// probe probes a single managed cluster
+	probe func(ctx context.Context, clusterName string) probeResult
+}
+
+// probeAll probes the managed clusters in parallel, each with a single use tunnel. The results are sorted
+// by the cluster names.
+func (p *prober) probeAll(ctx context.Context) ([]probeResult, error) {
+	managedClusterList, err := p.clusterClient.ManagedClusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed listing managed clusters")
+	}
+
+	probingClusters := p.options.ClusterOption.AllClusters()
+	var clusterNames []string
+	for _, cluster := range managedClusterList.Items {
+		if probingClusters.Len() == 0 || probingClusters.Has(cluster.Name) {
+			clusterNames = append(clusterNames, cluster.Name)
 		}
 	}
+	sort.Strings(clusterNames)
+
+	results := make([]probeResult, len(clusterNames))
+	var wg sync.WaitGroup
+	for i, clusterName := range clusterNames {
+		wg.Add(1)
+		go func(i int, clusterName string) {
+			defer wg.Done()
+			results[i] = p.probe(ctx, clusterName)
+		}(i, clusterName)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// probeTunnel probes the managed cluster through a konnectivity tunnel of the proxy-server.
+func (p *prober) probeTunnel(ctx context.Context, clusterName string) probeResult {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	tunnel, err := konnectivity.CreateSingleUseGrpcTunnelWithContext(
+		ctx,
+		ctx,
+		net.JoinHostPort(p.options.proxyServerHost, strconv.Itoa(p.options.proxyServerPort)),
+		grpc.WithTransportCredentials(grpccredentials.NewTLS(p.tlsCfg)),
+	)
+	if err != nil {
+		klog.Errorf("Failed starting konnectivity proxy for cluster %v: %v", clusterName, err)
+		return probeResult{Cluster: clusterName, Health: healthUnknown}
+	}
+
+	result, err := p.options.visit(ctx, p.hubRestConfig, p.addonClient, tunnel.DialContext, clusterName)
+	if err != nil {
+		klog.Errorf("An error occurred when requesting: %v", err)
+		return probeResult{Cluster: clusterName, Health: healthUnknown}
+	}
+	return result
+}
+
+func (o *Options) print(streams genericiooptions.IOStreams, results []probeResult) error {
+	if o.output == outputJSON {
+		return json.NewEncoder(streams.Out).Encode(probeReport{
+			Time:     metav1.Now(),
+			Clusters: results,
+		})
+	}
 
+	if o.interval > 0 {
+		_, _ = fmt.Fprintf(streams.Out, "\nProbed at %s\n", time.Now().Format(time.RFC3339))
+	}
+	w := newWriter(streams)
+	for _, result := range results {
+		latency := "<none>"
+		switch {
+		case result.TimedOut:
+			latency = "<timeout>"
+		case result.LatencySeconds != nil:
+			latency = time.Duration(*result.LatencySeconds * float64(time.Second)).String()
+		}
+		// TODO: use a common table convertor in the future.
+		w.print(result.Cluster, boolString(result.Installed), boolString(result.Available), result.Health, latency)
+	}
 	w.flush()
 	return nil
 }
 
+// probeReport is printed in the json format for each round of probing
+type probeReport struct {
+	Time     metav1.Time   `json:"time"`
+	Clusters []probeResult `json:"clusters"`
+}
+
+func boolString(b bool) string {
+	if b {
+		return healthTrue
+	}
+	return healthFalse
+}
+
 const (
 	inClusterSecretProxyCA = "proxy-server-ca"
 	inClusterSecretClient  = "proxy-client"
@@ -224,24 +386,22 @@ func (o *Options) getKonnectivityTLSConfig(proxyConfig *proxyv1alpha1.ManagedPro
 }
 
 func (o *Options) visit(
-	w *writer,
+	ctx context.Context,
 	hubRestConfig *rest.Config,
 	addonClient addonv1alpha1client.Interface,
 	dialFunc k8snet.DialFunc,
-	clusterName string) error {
+	clusterName string) (probeResult, error) {
+	result := probeResult{Cluster: clusterName, Health: healthFalse}
 
 	addon, err := addonClient.AddonV1alpha1().ManagedClusterAddOns(clusterName).
-		Get(context.TODO(), common.AddonName, metav1.GetOptions{})
-	installed := "True"
-	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			return errors.Wrapf(err, "failed getting managed cluster addon for cluster %v", clusterName)
-		}
-		installed = "False"
-	}
-	addonAvailable := "False"
-	if meta.IsStatusConditionTrue(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable) {
-		addonAvailable = "True"
+		Get(ctx, common.AddonName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return result, errors.Wrapf(err, "failed getting managed cluster addon for cluster %v", clusterName)
+	default:
+		result.Installed = true
+		result.Available = meta.IsStatusConditionTrue(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable)
 	}
 
 	copiedCfg := rest.CopyConfig(hubRestConfig)
@@ -258,41 +418,38 @@ func (o *Options) visit(
 
 	rt, err := rest.TransportFor(copiedCfg)
 	if err != nil {
-		return errors.Wrapf(err, "failed creating roundtripper for cluster %v", clusterName)
+		return result, errors.Wrapf(err, "failed creating roundtripper for cluster %v", clusterName)
 	}
-	req := &http.Request{
+	req := (&http.Request{
 		Method: "GET",
 		Host:   clusterName,
+		Header: http.Header{},
 		URL: &url.URL{
 			Scheme: "https",
 			Host:   clusterName,
 			Path:   "/healthz",
 		},
-	}
-	health := "False"
-	latency := "<none>"
+	}).WithContext(ctx)
 	start := time.Now()
 	resp, err := rt.RoundTrip(req)
 	if err != nil {
-		health = "Unknown"
+		result.Health = healthUnknown
 		klog.Errorf("Failed requesting /healthz endpoint for cluster %v: %v", clusterName, err)
-		if strings.Contains(err.Error(), "dial timeout") {
-			latency = "<timeout>"
+		if strings.Contains(err.Error(), "dial timeout") || errors.Is(err, context.DeadlineExceeded) {
+			result.TimedOut = true
 		}
-		w.print(clusterName, installed, addonAvailable, health, latency)
-		return nil
+		return result, nil
 	}
+	defer resp.Body.Close()
 
 	end := time.Now()
 	data, _ := io.ReadAll(resp.Body)
 	if string(data) == "ok" {
-		health = "True"
-		latency = end.Sub(start).String()
+		result.Health = healthTrue
+		latency := end.Sub(start).Seconds()
+		result.LatencySeconds = &latency
 	}
-
-	// TODO: use a common table convertor in the future.
-	w.print(clusterName, installed, addonAvailable, health, latency)
-	return nil
+	return result, nil
 }
 
 func buildTLSConfig(caData, certData, keyData []byte, serverName string, protos []string) (*tls.Config, error) {
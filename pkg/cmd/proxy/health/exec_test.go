@@ -0,0 +1,207 @@
+// Copyright Contributors to the Open Cluster Management project
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/rest"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	addonfake "open-cluster-management.io/api/client/addon/clientset/versioned/fake"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"open-cluster-management.io/cluster-proxy/pkg/common"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+func newCluster(name string) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func TestProbeAll(t *testing.T) {
+	cases := []struct {
+		name     string
+		clusters []string
+		expected []string
+	}{
+		{
+			name:     "all the clusters sorted by name",
+			expected: []string{"cluster1", "cluster2", "cluster3"},
+		},
+		{
+			name:     "the specified clusters",
+			clusters: []string{"cluster3", "cluster1", "cluster4"},
+			expected: []string{"cluster1", "cluster3"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterClient := clusterfake.NewSimpleClientset(newCluster("cluster3"), newCluster("cluster1"), newCluster("cluster2"))
+			p := &prober{
+				options:       &Options{ClusterOption: &genericclioptionsclusteradm.ClusterOption{Clusters: c.clusters}},
+				clusterClient: clusterClient.ClusterV1(),
+				probe: func(_ context.Context, clusterName string) probeResult {
+					return probeResult{Cluster: clusterName, Installed: true, Health: healthTrue}
+				},
+			}
+
+			results, err := p.probeAll(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var actual []string
+			for _, result := range results {
+				if !result.Installed || result.Health != healthTrue {
+					t.Errorf("unexpected result of cluster %s: %+v", result.Cluster, result)
+				}
+				actual = append(actual, result.Cluster)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected the results of clusters %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVisit(t *testing.T) {
+	cases := []struct {
+		name              string
+		addon             *addonv1alpha1.ManagedClusterAddOn
+		healthz           string
+		serverDown        bool
+		expectedInstalled bool
+		expectedAvailable bool
+		expectedHealth    string
+		expectedLatency   bool
+	}{
+		{
+			name: "healthy",
+			addon: &addonv1alpha1.ManagedClusterAddOn{
+				ObjectMeta: metav1.ObjectMeta{Name: common.AddonName, Namespace: "cluster1"},
+				Status: addonv1alpha1.ManagedClusterAddOnStatus{Conditions: []metav1.Condition{
+					{Type: addonv1alpha1.ManagedClusterAddOnConditionAvailable, Status: metav1.ConditionTrue},
+				}},
+			},
+			healthz:           "ok",
+			expectedInstalled: true,
+			expectedAvailable: true,
+			expectedHealth:    healthTrue,
+			expectedLatency:   true,
+		},
+		{
+			name: "unavailable addon",
+			addon: &addonv1alpha1.ManagedClusterAddOn{
+				ObjectMeta: metav1.ObjectMeta{Name: common.AddonName, Namespace: "cluster1"},
+			},
+			healthz:           "ok",
+			expectedInstalled: true,
+			expectedHealth:    healthTrue,
+			expectedLatency:   true,
+		},
+		{
+			name:           "not healthy",
+			healthz:        "[-]etcd failed",
+			expectedHealth: healthFalse,
+		},
+		{
+			name:           "request failed",
+			serverDown:     true,
+			expectedHealth: healthUnknown,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/healthz" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(c.healthz))
+			}))
+			defer server.Close()
+			addr := server.Listener.Addr().String()
+			if c.serverDown {
+				server.Close()
+			}
+			// the tunnel to the managed cluster is replaced by a connection to the test server
+			dial := func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+			}
+
+			var objs []runtime.Object
+			if c.addon != nil {
+				objs = append(objs, c.addon)
+			}
+			hubRestConfig := &rest.Config{
+				Host:  "https://hub",
+				Proxy: func(*http.Request) (*url.URL, error) { return nil, nil },
+			}
+
+			o := &Options{}
+			result, err := o.visit(context.TODO(), hubRestConfig, addonfake.NewSimpleClientset(objs...), dial, "cluster1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Cluster != "cluster1" || result.Installed != c.expectedInstalled ||
+				result.Available != c.expectedAvailable || result.Health != c.expectedHealth {
+				t.Errorf("unexpected result %+v", result)
+			}
+			if (result.LatencySeconds != nil) != c.expectedLatency {
+				t.Errorf("expected latency %v, got %v", c.expectedLatency, result.LatencySeconds)
+			}
+		})
+	}
+}
+
+func TestPrintJSON(t *testing.T) {
+	latency := 0.25
+	results := []probeResult{
+		{Cluster: "cluster1", Installed: true, Available: true, Health: healthTrue, LatencySeconds: &latency},
+		{Cluster: "cluster2", Installed: true, Health: healthUnknown, TimedOut: true},
+		{Cluster: "cluster3", Health: healthFalse},
+	}
+
+	out := &bytes.Buffer{}
+	o := &Options{output: outputJSON}
+	if err := o.print(genericiooptions.IOStreams{Out: out}, results); err != nil {
+		t.Fatal(err)
+	}
+
+	report := probeReport{}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode the report %s: %v", out.String(), err)
+	}
+	if report.Time.IsZero() {
+		t.Errorf("expected the time of the report")
+	}
+	if !reflect.DeepEqual(report.Clusters, results) {
+		t.Errorf("expected the results %+v, got %+v", results, report.Clusters)
+	}
+
+	// the optional fields are omitted
+	raw := struct {
+		Clusters []map[string]interface{} `json:"clusters"`
+	}{}
+	if err := json.Unmarshal(out.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"latencySeconds", "timedOut"} {
+		if _, ok := raw.Clusters[2][field]; ok {
+			t.Errorf("expected %s omitted for cluster3, got %v", field, raw.Clusters[2])
+		}
+	}
+}
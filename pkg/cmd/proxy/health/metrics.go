@@ -0,0 +1,99 @@
+// Copyright Contributors to the Open Cluster Management project
+package health
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/util/runtime"
+)
+
+const metricsNamespace = "clusteradm_cluster_proxy"
+
+// probeMetrics exposes the results of probing as prometheus metrics labeled with the cluster names
+type probeMetrics struct {
+	registry  *prometheus.Registry
+	installed *prometheus.GaugeVec
+	available *prometheus.GaugeVec
+	healthy   *prometheus.GaugeVec
+	latency   *prometheus.HistogramVec
+}
+
+func newProbeMetrics() *probeMetrics {
+	m := &probeMetrics{
+		registry: prometheus.NewRegistry(),
+		installed: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "addon_installed",
+			Help:      "Whether the cluster-proxy addon is installed on the managed cluster.",
+		}, []string{"cluster"}),
+		available: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "addon_available",
+			Help:      "Whether the cluster-proxy addon is available on the managed cluster.",
+		}, []string{"cluster"}),
+		healthy: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "healthy",
+			Help:      "Whether the /healthz endpoint of the managed cluster responds ok through cluster-proxy.",
+		}, []string{"cluster"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "probe_latency_seconds",
+			Help:      "The latency of requesting the /healthz endpoint of the managed cluster through cluster-proxy.",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+		}, []string{"cluster"}),
+	}
+	m.registry.MustRegister(m.installed, m.available, m.healthy, m.latency)
+	return m
+}
+
+// record sets the gauges to the results, the gauges of the clusters not probed anymore are removed.
+func (m *probeMetrics) record(results []probeResult) {
+	m.installed.Reset()
+	m.available.Reset()
+	m.healthy.Reset()
+	for _, result := range results {
+		m.installed.WithLabelValues(result.Cluster).Set(boolGauge(result.Installed))
+		m.available.WithLabelValues(result.Cluster).Set(boolGauge(result.Available))
+		m.healthy.WithLabelValues(result.Cluster).Set(boolGauge(result.Health == healthTrue))
+		if result.LatencySeconds != nil {
+			m.latency.WithLabelValues(result.Cluster).Observe(*result.LatencySeconds)
+		}
+	}
+}
+
+// serve exposes the metrics on /metrics of the address until the context is done.
+func (m *probeMetrics) serve(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed listening on %s", addr)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{Handler: mux}
+	go func() {
+		if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			runtime.HandleError(errors.Wrapf(err, "failed to serve metrics"))
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		if err := srv.Shutdown(context.Background()); err != nil {
+			runtime.HandleError(errors.Wrapf(err, "failed to shutdown metrics server"))
+		}
+	}()
+	return nil
+}
+
+func boolGauge(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
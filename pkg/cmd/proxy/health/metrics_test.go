@@ -0,0 +1,68 @@
+// Copyright Contributors to the Open Cluster Management project
+package health
+
+import (
+	"reflect"
+	"testing"
+)
+
+// gatherMetrics returns the value of the gauges and the sample count of the histograms per metric and cluster.
+func gatherMetrics(t *testing.T, m *probeMetrics) map[string]map[string]float64 {
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]map[string]float64{}
+	for _, family := range families {
+		values[family.GetName()] = map[string]float64{}
+		for _, metric := range family.GetMetric() {
+			var cluster string
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "cluster" {
+					cluster = label.GetValue()
+				}
+			}
+			switch {
+			case metric.GetGauge() != nil:
+				values[family.GetName()][cluster] = metric.GetGauge().GetValue()
+			case metric.GetHistogram() != nil:
+				values[family.GetName()][cluster] = float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return values
+}
+
+func TestRecordMetrics(t *testing.T) {
+	latency := 0.1
+	m := newProbeMetrics()
+	m.record([]probeResult{
+		{Cluster: "cluster1", Installed: true, Available: true, Health: healthTrue, LatencySeconds: &latency},
+		{Cluster: "cluster2", Installed: true, Health: healthUnknown, TimedOut: true},
+		{Cluster: "cluster3", Health: healthFalse},
+	})
+
+	expected := map[string]map[string]float64{
+		"clusteradm_cluster_proxy_addon_installed":       {"cluster1": 1, "cluster2": 1, "cluster3": 0},
+		"clusteradm_cluster_proxy_addon_available":       {"cluster1": 1, "cluster2": 0, "cluster3": 0},
+		"clusteradm_cluster_proxy_healthy":               {"cluster1": 1, "cluster2": 0, "cluster3": 0},
+		"clusteradm_cluster_proxy_probe_latency_seconds": {"cluster1": 1},
+	}
+	if actual := gatherMetrics(t, m); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected metrics %v, got %v", expected, actual)
+	}
+
+	// the gauges of the clusters not probed anymore are removed, and the latency is observed per probe
+	m.record([]probeResult{
+		{Cluster: "cluster1", Installed: true, Available: true, Health: healthTrue, LatencySeconds: &latency},
+	})
+	expected = map[string]map[string]float64{
+		"clusteradm_cluster_proxy_addon_installed":       {"cluster1": 1},
+		"clusteradm_cluster_proxy_addon_available":       {"cluster1": 1},
+		"clusteradm_cluster_proxy_healthy":               {"cluster1": 1},
+		"clusteradm_cluster_proxy_probe_latency_seconds": {"cluster1": 2},
+	}
+	if actual := gatherMetrics(t, m); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected metrics %v, got %v", expected, actual)
+	}
+}
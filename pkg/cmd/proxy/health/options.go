@@ -2,6 +2,8 @@
 package health
 
 import (
+	"time"
+
 	"k8s.io/cli-runtime/pkg/genericiooptions"
 
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
@@ -19,6 +21,9 @@ type Options struct {
 	proxyClientKeyPath       string
 	proxyServerHost          string
 	proxyServerPort          int
+	interval                 time.Duration
+	metricsAddr              string
+	output                   string
 
 	// completed fields
 	isProxyClientCertProvided    bool
@@ -2,36 +2,22 @@
 package kubectl
 
 import (
+	"bufio"
 	"context"
 	"fmt"
-	"os"
-	"os/exec"
 	"strings"
-	"sync/atomic"
-
-	"bufio"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
-	"k8s.io/klog/v2"
 
-	addonv1alpha1client "open-cluster-management.io/api/client/addon/clientset/versioned"
 	clusterv1 "open-cluster-management.io/api/client/cluster/clientset/versioned/typed/cluster/v1"
 	proxyv1alpha1 "open-cluster-management.io/cluster-proxy/pkg/apis/proxy/v1alpha1"
-	"open-cluster-management.io/cluster-proxy/pkg/common"
-	clusterproxyclient "open-cluster-management.io/cluster-proxy/pkg/generated/clientset/versioned"
-	"open-cluster-management.io/cluster-proxy/pkg/util"
-	"open-cluster-management.io/clusteradm/pkg/config"
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
-	msaclientset "open-cluster-management.io/managed-serviceaccount/pkg/generated/clientset/versioned"
+	"open-cluster-management.io/clusteradm/pkg/helpers/clusterproxy"
 )
 
 func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
@@ -41,15 +27,22 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 	var proxyConfig *proxyv1alpha1.ManagedProxyConfiguration
 
 	cmd := &cobra.Command{
-		Use:   "kubectl",
+		Use:   "kubectl [flags] -- <kubectl args>",
 		Short: "Use kubectl through cluster-proxy addon.",
-		Long:  "Use kubectl through cluster-proxy addon. (Only supports managed service account token as certificate.)",
+		Long: "Use kubectl through cluster-proxy addon. The managed cluster is accessed with the token of a managed service account, " +
+			"the token of the hub user impersonated by cluster-proxy, or a temporary managed service account created for the command.",
 		Example: `If you want to get nodes on managed cluster named "cluster1", you can use the following command:
-		clusteradm proxy kubectl --cluster=cluster1 --sa=test --args="get nodes"`,
-		SilenceUsage: true,
+		clusteradm proxy kubectl --cluster=cluster1 --sa=test -- get nodes
+If you want to access "cluster1" as the current hub user:
+		clusteradm proxy kubectl --cluster=cluster1 --impersonate -- get pods -A
+If you want to access "cluster1" with a temporary managed service account bound to the view cluster role:
+		clusteradm proxy kubectl --cluster=cluster1 --temporary-sa-cluster-role=view --temporary-sa-ttl=30m -- get pods -A`,
+		SilenceUsage:      true,
+		ValidArgsFunction: completeKubectlArgs,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 
+			o.complete(args)
 			if err = o.validate(); err != nil {
 				return err
 			}
@@ -61,7 +54,7 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 			}
 
 			// get proxyConfig
-			proxyConfig, err = getProxyConfig(hubRestConfig, streams)
+			proxyConfig, err = clusterproxy.GetProxyConfig(hubRestConfig, streams)
 			if err != nil {
 				return err
 			}
@@ -69,6 +62,10 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if proxyConfig == nil {
+				return nil
+			}
+
 			// Get managedcluster
 			clusterClient, err := clusterv1.NewForConfig(hubRestConfig)
 			if err != nil {
@@ -79,217 +76,109 @@ func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, stream
 				return err
 			}
 
-			// Get managedServiceAccount
-			managedServiceAccountToken, err := getManagedServiceAccountToken(hubRestConfig, o.managedServiceAccount, o.ClusterOption.Cluster)
+			// Get the token of the managedServiceAccount or the hub user
+			token, releaseToken, err := o.getToken(cmd.Context(), hubRestConfig)
 			if err != nil {
 				return err
 			}
+			defer releaseToken()
 
 			// Get Proxy Certificates
-			proxyCertificates, err := getProxyCertificates(hubRestConfig, proxyConfig)
+			proxyCertificates, err := clusterproxy.GetProxyCertificates(hubRestConfig, proxyConfig)
 			if err != nil {
 				return err
 			}
 
 			// Run port-forward in goroutine
-			readiness := &atomic.Value{}
-			readiness.Store(true)
-			localProxy := util.NewRoundRobinLocalProxy(
+			proxyServerPort, portForwardClose, err := clusterproxy.ListenLocalProxy(
+				cmd.Context(),
 				hubRestConfig,
-				readiness,
-				proxyConfig.Spec.ProxyServer.Namespace,
-				common.LabelKeyComponentName+"="+common.ComponentNameProxyServer,
-				int32(8090), // TODO make it configurable or random later
+				proxyConfig,
+				o.proxyServerPort,
 			)
-			portForwardClose, err := localProxy.Listen(cmd.Context())
 			if err != nil {
-				return errors.Wrapf(err, "failed listening local proxy")
+				return err
 			}
 			defer portForwardClose()
 
 			// Run a http-proxy-server in goroutine
-			hps, err := newHTTPProxyServer(
+			hps, err := clusterproxy.NewHTTPProxyServer(
 				cmd.Context(),
 				o.ClusterOption.Cluster,
-				int32(8090), // TODO make it configurable or random later
+				proxyServerPort,
 				proxyCertificates,
 			)
 			if err != nil {
 				return err
 			}
-			err = hps.Listen(cmd.Context(), int32(9090)) // TODO make it configurable or random later
+			localPort, err := hps.Listen(cmd.Context(), o.localPort)
 			if err != nil {
 				return errors.Wrapf(err, "failed listening http proxy server")
 			}
 
-			// Configure a customized kubeconfig amd write into /tmp dir with a random name
-			tmpKubeconfigFilePath, err := genTmpKubeconfig(o.ClusterOption.Cluster, managedServiceAccountToken)
-			if err != nil {
-				return err
-			}
-			defer os.Remove(tmpKubeconfigFilePath)
-			klog.V(4).Infof("kubeconfig file path is %s", tmpKubeconfigFilePath)
+			getter := newRESTClientGetter(fmt.Sprintf("https://localhost:%d", localPort), token)
 
 			if o.interactiveMode {
 				if _, err = streams.Out.Write([]byte("Please enter the kubectl command and use \"exit\" to quit the interactive mode\n")); err != nil {
 					return errors.Wrap(err, "streams out write failed")
 				}
 
+				reader := bufio.NewReader(streams.In)
 				for {
 					// user input kubectl commands and use "exit" to quit the interactive mode
 					if _, err = streams.Out.Write([]byte("kubectl> ")); err != nil {
 						return errors.Wrap(err, "streams out write failed")
 					}
-					reader := bufio.NewReader(os.Stdin)
 					input, err := reader.ReadString('\n')
 					if err != nil {
 						return errors.Wrap(err, "read input failed")
 					}
-					input = strings.TrimRight(input, "\n")
+					input = strings.TrimSpace(input)
 					if input == "exit" {
-						if _, err = streams.Out.Write([]byte("Exit from interactive mode")); err != nil {
+						if _, err = streams.Out.Write([]byte("Exit from interactive mode\n")); err != nil {
 							return errors.Wrap(err, "streams out write failed")
 						}
 						break
 					}
+					if len(input) == 0 {
+						continue
+					}
 
-					// Using kubectl to access the managed cluster using the above customized kubeconfig
-					// We are using combinedoutput, so err msg should include in result, no need to handle err
-					result, _ := runKubectlCommand(tmpKubeconfigFilePath, strings.TrimRight(input, "\n"))
-					if _, err = streams.Out.Write(result); err != nil {
-						return errors.Wrap(err, "streams out write failed, exit the interactive mode")
+					// A failed command does not quit the interactive mode, print the error and continue
+					args, err := splitArgs(input)
+					if err == nil {
+						err = runKubectl(cmd.Context(), getter, streams, args)
+					}
+					if err != nil {
+						if _, err = fmt.Fprintf(streams.ErrOut, "Error: %v\n", err); err != nil {
+							return errors.Wrap(err, "streams err write failed, exit the interactive mode")
+						}
 					}
 				}
-			} else {
-				// Using kubectl to access the managed cluster using the above customized kubeconfig
-				// We are using combinedoutput, so err msg should include in result, no need to handle err.
-				result, _ := runKubectlCommand(tmpKubeconfigFilePath, o.kubectlArgs)
-				if _, err = streams.Out.Write(result); err != nil {
-					return errors.Wrap(err, "streams out write failed")
-				}
+				return nil
 			}
-			return nil
+
+			return runKubectl(cmd.Context(), getter, streams, o.kubectlArgs)
 		},
 	}
 
+	// the flags after the first kubectl arg are passed to kubectl
+	cmd.Flags().SetInterspersed(false)
 	o.ClusterOption.AddFlags(cmd.Flags())
 	cmd.Flags().StringVar(&o.managedServiceAccount, "sa", "", "The name of the managedServiceAccount")
-	cmd.Flags().StringVar(&o.kubectlArgs, "args", "", "The arguments to pass to kubectl")
+	cmd.Flags().BoolVar(&o.impersonate, "impersonate", false,
+		"Access the managed cluster as the current hub user, which requires the impersonation of cluster-proxy to be enabled")
+	cmd.Flags().StringVar(&o.temporarySAClusterRole, "temporary-sa-cluster-role", "",
+		"Create a temporary managedServiceAccount bound to this cluster role on the managed cluster, which is deleted on exit")
+	cmd.Flags().DurationVar(&o.temporarySATTL, "temporary-sa-ttl", time.Hour,
+		"The time to live of the temporary managedServiceAccount, it is deleted after the ttl even if the command does not exit normally")
+	cmd.Flags().StringVar(&o.deprecatedKubectlArgs, "args", "", "The arguments to pass to kubectl")
+	_ = cmd.Flags().MarkDeprecated("args", "pass the kubectl arguments as positional arguments instead")
 	cmd.Flags().BoolVarP(&o.interactiveMode, "interactive-mode", "i", false, "Enter the interactive mode")
+	cmd.Flags().Int32Var(&o.localPort, "local-port", 0,
+		"The local port serving the kube-apiserver of the managed cluster, a free port is allocated if it is 0")
+	cmd.Flags().Int32Var(&o.proxyServerPort, "proxy-server-port", 0,
+		"The local port forwarded to the proxy-server on the hub, a free port is allocated if it is 0")
 
 	return cmd
 }
-
-func getProxyConfig(hubRestConfig *rest.Config, streams genericiooptions.IOStreams) (*proxyv1alpha1.ManagedProxyConfiguration, error) {
-	addonClient, err := addonv1alpha1client.NewForConfig(hubRestConfig)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed initializing addon api client")
-	}
-
-	_, err = addonClient.AddonV1alpha1().ClusterManagementAddOns().Get(
-		context.TODO(),
-		"cluster-proxy",
-		metav1.GetOptions{})
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			if _, err := fmt.Fprintf(
-				streams.Out,
-				"Cluster-Proxy addon is not installed.\n"); err != nil {
-				return nil, err
-			}
-			if _, err := fmt.Fprintf(
-				streams.Out,
-				"Consider following the guide: https://open-cluster-management.io/getting-started/integration/cluster-proxy/\n"); err != nil {
-				return nil, err
-			}
-			return nil, nil
-		}
-		return nil, errors.Wrapf(err, "failed checking cluster management addon for cluster-proxy")
-	}
-
-	proxyClient, err := clusterproxyclient.NewForConfig(hubRestConfig)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed initializing proxy api client")
-	}
-
-	proxyConfig, err := proxyClient.ProxyV1alpha1().ManagedProxyConfigurations().
-		Get(context.TODO(), config.ManagedProxyConfigurationName, metav1.GetOptions{})
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed getting managedproxyconfiguration for cluster-proxy")
-	}
-
-	return proxyConfig, nil
-}
-
-func getManagedServiceAccountToken(hubRestConfig *rest.Config, msaName string, namespace string) (string, error) {
-	msaClient, err := msaclientset.NewForConfig(hubRestConfig)
-	if err != nil {
-		return "", err
-	}
-
-	msa, err := msaClient.AuthenticationV1beta1().ManagedServiceAccounts(namespace).Get(context.TODO(), msaName, metav1.GetOptions{})
-	if err != nil {
-		return "", err
-	}
-
-	kubeClient, err := kubernetes.NewForConfig(hubRestConfig)
-	if err != nil {
-		return "", err
-	}
-	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), msa.Status.TokenSecretRef.Name, metav1.GetOptions{})
-	if err != nil {
-		return "", err
-	}
-
-	token, ok := secret.Data["token"]
-	if !ok {
-		return "", errors.Errorf("token is not found in secret %s", secret.Name)
-	}
-
-	return string(token), nil
-}
-
-// Configure a tmp kubeconfig and store it in a tmp file
-func genTmpKubeconfig(cluster string, msaToken string) (string, error) {
-	c := &clientcmdapi.Cluster{
-		Server:                "https://localhost:9090",
-		InsecureSkipTLSVerify: true, // Because we are using a local proxy
-	}
-
-	kubeconfigContent, err := clientcmd.Write(clientcmdapi.Config{
-		Kind:       "Config",
-		APIVersion: "v1",
-		Clusters: map[string]*clientcmdapi.Cluster{
-			"cluster": c,
-		},
-		Contexts: map[string]*clientcmdapi.Context{
-			"context": {
-				Cluster:  "cluster",
-				AuthInfo: "user",
-			},
-		},
-		AuthInfos: map[string]*clientcmdapi.AuthInfo{
-			"user": {
-				Token: msaToken,
-			},
-		},
-		CurrentContext: "context",
-	})
-	if err != nil {
-		return "", nil
-	}
-
-	tmpFile := fmt.Sprintf("/tmp/%s-%s.kubeconfig", cluster, uuid.New().String())
-	if err := os.WriteFile(tmpFile, kubeconfigContent, 0644); err != nil {
-		return "", err
-	}
-
-	return tmpFile, nil
-}
-
-// Use runKubectlCommand to access the managed cluster
-func runKubectlCommand(kubeconfigFilePath string, args string) ([]byte, error) {
-	cmd := exec.Command("kubectl", strings.Split(args, " ")...)
-	cmd.Env = append(os.Environ(), fmt.Sprintf("KUBECONFIG=%s", kubeconfigFilePath))
-	return cmd.CombinedOutput()
-}
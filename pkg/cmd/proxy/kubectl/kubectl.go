@@ -0,0 +1,275 @@
+// Copyright Contributors to the Open Cluster Management project
+package kubectl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/cmd/annotate"
+	"k8s.io/kubectl/pkg/cmd/apiresources"
+	"k8s.io/kubectl/pkg/cmd/apply"
+	"k8s.io/kubectl/pkg/cmd/attach"
+	"k8s.io/kubectl/pkg/cmd/auth"
+	"k8s.io/kubectl/pkg/cmd/clusterinfo"
+	"k8s.io/kubectl/pkg/cmd/cp"
+	"k8s.io/kubectl/pkg/cmd/create"
+	"k8s.io/kubectl/pkg/cmd/delete"
+	"k8s.io/kubectl/pkg/cmd/describe"
+	"k8s.io/kubectl/pkg/cmd/drain"
+	"k8s.io/kubectl/pkg/cmd/edit"
+	"k8s.io/kubectl/pkg/cmd/events"
+	"k8s.io/kubectl/pkg/cmd/exec"
+	"k8s.io/kubectl/pkg/cmd/explain"
+	"k8s.io/kubectl/pkg/cmd/get"
+	"k8s.io/kubectl/pkg/cmd/label"
+	"k8s.io/kubectl/pkg/cmd/logs"
+	"k8s.io/kubectl/pkg/cmd/patch"
+	"k8s.io/kubectl/pkg/cmd/portforward"
+	"k8s.io/kubectl/pkg/cmd/replace"
+	"k8s.io/kubectl/pkg/cmd/rollout"
+	"k8s.io/kubectl/pkg/cmd/scale"
+	"k8s.io/kubectl/pkg/cmd/taint"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/cmd/wait"
+
+	"open-cluster-management.io/clusteradm/pkg/helpers"
+)
+
+const kubectlName = "kubectl"
+
+// restClientGetter provides the clients of the managed cluster to the kubectl commands from an
+// in-memory kubeconfig, so no kubeconfig file is written to the disk.
+type restClientGetter struct {
+	kubeconfig clientcmdapi.Config
+	namespace  *string
+}
+
+var _ genericclioptions.RESTClientGetter = &restClientGetter{}
+
+// newRESTClientGetter returns a restClientGetter accessing the server with the token.
+func newRESTClientGetter(server, token string) *restClientGetter {
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters["cluster"] = &clientcmdapi.Cluster{
+		Server:                server,
+		InsecureSkipTLSVerify: true, // Because we are using a local proxy
+	}
+	kubeconfig.AuthInfos["user"] = &clientcmdapi.AuthInfo{
+		Token: token,
+	}
+	kubeconfig.Contexts["context"] = &clientcmdapi.Context{
+		Cluster:  "cluster",
+		AuthInfo: "user",
+	}
+	kubeconfig.CurrentContext = "context"
+
+	namespace := ""
+	return &restClientGetter{
+		kubeconfig: *kubeconfig,
+		namespace:  &namespace,
+	}
+}
+
+func (g *restClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	overrides := &clientcmd.ConfigOverrides{}
+	overrides.Context.Namespace = *g.namespace
+	return clientcmd.NewDefaultClientConfig(g.kubeconfig, overrides)
+}
+
+func (g *restClientGetter) ToRESTConfig() (*rest.Config, error) {
+	return g.ToRawKubeConfigLoader().ClientConfig()
+}
+
+func (g *restClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	restConfig, err := g.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return memory.NewMemCacheClient(discoveryClient), nil
+}
+
+func (g *restClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	discoveryClient, err := g.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	return restmapper.NewShortcutExpander(mapper, discoveryClient, func(warning string) {
+		klog.Warning(warning)
+	}), nil
+}
+
+// newKubectlCommand builds the kubectl command tree running against the clients of the getter.
+func newKubectlCommand(getter *restClientGetter, streams genericiooptions.IOStreams) *cobra.Command {
+	f := cmdutil.NewFactory(getter)
+
+	cmd := &cobra.Command{
+		Use:           kubectlName,
+		Short:         "kubectl controls the managed cluster through cluster-proxy",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Run: func(c *cobra.Command, args []string) {
+			_ = c.Help()
+		},
+	}
+	cmd.SetIn(streams.In)
+	cmd.SetOut(streams.Out)
+	cmd.SetErr(streams.ErrOut)
+	cmd.PersistentFlags().StringVarP(getter.namespace, "namespace", "n", "", "If present, the namespace scope for this CLI request")
+
+	cmd.AddCommand(
+		annotate.NewCmdAnnotate(kubectlName, f, streams),
+		apiresources.NewCmdAPIResources(getter, streams),
+		apiresources.NewCmdAPIVersions(getter, streams),
+		apply.NewCmdApply(kubectlName, f, streams),
+		attach.NewCmdAttach(f, streams),
+		auth.NewCmdAuth(f, streams),
+		clusterinfo.NewCmdClusterInfo(getter, streams),
+		cp.NewCmdCp(f, streams),
+		create.NewCmdCreate(f, streams),
+		delete.NewCmdDelete(f, streams),
+		describe.NewCmdDescribe(kubectlName, f, streams),
+		drain.NewCmdCordon(f, streams),
+		drain.NewCmdDrain(f, streams),
+		drain.NewCmdUncordon(f, streams),
+		edit.NewCmdEdit(f, streams),
+		events.NewCmdEvents(getter, streams),
+		exec.NewCmdExec(f, streams),
+		explain.NewCmdExplain(kubectlName, f, streams),
+		get.NewCmdGet(kubectlName, f, streams),
+		label.NewCmdLabel(f, streams),
+		logs.NewCmdLogs(f, streams),
+		patch.NewCmdPatch(f, streams),
+		portforward.NewCmdPortForward(f, streams),
+		replace.NewCmdReplace(f, streams),
+		rollout.NewCmdRollout(f, streams),
+		scale.NewCmdScale(f, streams),
+		taint.NewCmdTaint(f, streams),
+		wait.NewCmdWait(getter, streams),
+	)
+
+	return cmd
+}
+
+// fatalError is raised by the kubectl commands instead of exiting the process.
+type fatalError struct {
+	msg  string
+	code int
+}
+
+// runKubectl runs the kubectl command with the args in-process. The errors which make kubectl exit
+// are returned instead, with the exit code of kubectl.
+func runKubectl(ctx context.Context, getter *restClientGetter, streams genericiooptions.IOStreams, args []string) (err error) {
+	cmdutil.BehaviorOnFatal(func(msg string, code int) {
+		panic(fatalError{msg: msg, code: code})
+	})
+	defer cmdutil.DefaultBehaviorOnFatal()
+	defer func() {
+		if r := recover(); r != nil {
+			fatal, ok := r.(fatalError)
+			if !ok {
+				panic(r)
+			}
+			msg := strings.TrimPrefix(strings.TrimSpace(fatal.msg), "error: ")
+			if len(msg) == 0 {
+				msg = fmt.Sprintf("kubectl exited with code %d", fatal.code)
+			}
+			err = &helpers.ExitError{Code: fatal.code, Err: fmt.Errorf("%s", msg)}
+		}
+	}()
+
+	cmd := newKubectlCommand(getter, streams)
+	cmd.SetArgs(args)
+	return cmd.ExecuteContext(ctx)
+}
+
+// splitArgs splits the input of the interactive mode into the kubectl args like a shell, the args are separated by
+// the spaces which are not quoted or escaped. The single quotes keep the text as it is, and the backslash escapes
+// the next character, or the quote, backslash and dollar sign in the double quotes.
+func splitArgs(input string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range input {
+		switch {
+		case escaped:
+			if quote == '"' && !strings.ContainsRune("\\\"$`", r) {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("the input ends with an unterminated escape")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("the input has an unterminated quote %c", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// completeKubectlArgs completes the kubectl args with the completions of the kubectl command tree. No
+// connection to the managed cluster is established, so only the commands and flags are completed.
+func completeKubectlArgs(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	out := &bytes.Buffer{}
+	streams := genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: out, ErrOut: &bytes.Buffer{}}
+	completeArgs := append(append([]string{cobra.ShellCompRequestCmd}, args...), toComplete)
+	if err := runKubectl(context.Background(), newRESTClientGetter("", ""), streams, completeArgs); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// the output is the completions per line followed by the directive in the format of :<directive>
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[len(lines)-1], ":") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	directive, err := strconv.Atoi(strings.TrimPrefix(lines[len(lines)-1], ":"))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return lines[:len(lines)-1], cobra.ShellCompDirective(directive)
+}
@@ -0,0 +1,95 @@
+// Copyright Contributors to the Open Cluster Management project
+package kubectl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/helpers"
+)
+
+func TestSplitArgs(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "plain args",
+			input:    "get pods  -n\tdefault",
+			expected: []string{"get", "pods", "-n", "default"},
+		},
+		{
+			name:     "single quotes",
+			input:    `get pods -l 'app in (web, db)' -o 'jsonpath={.items[*].metadata.name}'`,
+			expected: []string{"get", "pods", "-l", "app in (web, db)", "-o", "jsonpath={.items[*].metadata.name}"},
+		},
+		{
+			name:     "double quotes with escapes",
+			input:    `annotate pod p1 "note=say \"hi\" \n" x="a b"`,
+			expected: []string{"annotate", "pod", "p1", `note=say "hi" \n`, "x=a b"},
+		},
+		{
+			name:     "backslash in single quotes",
+			input:    `exec p1 -- sh -c 'echo \"$HOME\"'`,
+			expected: []string{"exec", "p1", "--", "sh", "-c", `echo \"$HOME\"`},
+		},
+		{
+			name:     "escaped space",
+			input:    `get configmap my\ config`,
+			expected: []string{"get", "configmap", "my config"},
+		},
+		{
+			name:     "empty quoted arg",
+			input:    `label pod p1 app- ""`,
+			expected: []string{"label", "pod", "p1", "app-", ""},
+		},
+		{
+			name:      "unterminated quote",
+			input:     `get pods -l 'app=web`,
+			expectErr: true,
+		},
+		{
+			name:      "unterminated escape",
+			input:     `get pods \`,
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			args, err := splitArgs(c.input)
+			if c.expectErr {
+				if err == nil {
+					t.Errorf("expected an error, got args %q", args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(args, c.expected) {
+				t.Errorf("expected args %q, got %q", c.expected, args)
+			}
+		})
+	}
+}
+
+func TestRunKubectlExitCode(t *testing.T) {
+	streams := genericiooptions.IOStreams{In: &bytes.Buffer{}, Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	err := runKubectl(context.Background(), newRESTClientGetter("", ""), streams, []string{"exec"})
+
+	var exitErr *helpers.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected an exit error, got %v", err)
+	}
+	if exitErr.Code != 1 {
+		t.Errorf("expected exit code 1, got %d", exitErr.Code)
+	}
+}
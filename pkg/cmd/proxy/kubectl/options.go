@@ -2,11 +2,23 @@
 package kubectl
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/kustomize/kyaml/errors"
 
 	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	"open-cluster-management.io/clusteradm/pkg/helpers/clusterproxy"
 )
 
+// minTemporarySATTL is the minimum validity of the token requested for a service account
+const minTemporarySATTL = 10 * time.Minute
+
 // Options: only support use in-cluster certificates
 type Options struct {
 	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
@@ -14,8 +26,23 @@ type Options struct {
 
 	ClusterOption         *genericclioptionsclusteradm.ClusterOption
 	managedServiceAccount string
-	kubectlArgs           string
+	kubectlArgs           []string
 	interactiveMode       bool
+
+	// localPort is the local port of the http proxy server, and proxyServerPort is the local port
+	// forwarded to the proxy-server. Free ports are allocated if they are 0.
+	localPort       int32
+	proxyServerPort int32
+
+	// impersonate forwards the token of the hub user, which is impersonated on the managed cluster
+	impersonate bool
+	// temporarySAClusterRole is the cluster role bound to a temporary managed service account created
+	// for the command, and temporarySATTL is the time to live of the managed service account.
+	temporarySAClusterRole string
+	temporarySATTL         time.Duration
+
+	// deprecatedKubectlArgs is the value of the deprecated --args flag
+	deprecatedKubectlArgs string
 }
 
 func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags) *Options {
@@ -25,12 +52,70 @@ func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags) *O
 	}
 }
 
+func (o *Options) complete(args []string) {
+	o.kubectlArgs = args
+	if len(o.deprecatedKubectlArgs) > 0 {
+		o.kubectlArgs = append(strings.Fields(o.deprecatedKubectlArgs), o.kubectlArgs...)
+	}
+}
+
 func (o *Options) validate() error {
 	if err := o.ClusterOption.Validate(); err != nil {
 		return err
 	}
-	if o.managedServiceAccount == "" {
-		return errors.Errorf("managedServiceAccount is required")
+	authOptions := 0
+	for _, set := range []bool{len(o.managedServiceAccount) > 0, o.impersonate, len(o.temporarySAClusterRole) > 0} {
+		if set {
+			authOptions++
+		}
+	}
+	if authOptions != 1 {
+		return errors.Errorf("exactly one of --sa, --impersonate and --temporary-sa-cluster-role is required")
+	}
+	if len(o.temporarySAClusterRole) > 0 && o.temporarySATTL < minTemporarySATTL {
+		return errors.Errorf("--temporary-sa-ttl must be at least %s", minTemporarySATTL)
+	}
+	if len(o.kubectlArgs) == 0 && !o.interactiveMode {
+		return errors.Errorf("the kubectl arguments are required if not in the interactive mode")
+	}
+	if o.localPort < 0 || o.proxyServerPort < 0 {
+		return errors.Errorf("--local-port and --proxy-server-port must not be negative")
 	}
 	return nil
 }
+
+// getToken returns the token to access the managed cluster and a func releasing it. A temporary managed
+// service account is also deleted if the command is interrupted.
+func (o *Options) getToken(ctx context.Context, hubRestConfig *rest.Config) (string, func(), error) {
+	switch {
+	case o.impersonate:
+		token, err := clusterproxy.GetHubUserToken(hubRestConfig)
+		return token, func() {}, err
+	case len(o.temporarySAClusterRole) > 0:
+		token, cleanup, err := clusterproxy.CreateTemporaryServiceAccount(ctx, hubRestConfig, o.ClusterOption.Cluster,
+			o.temporarySAClusterRole, o.temporarySATTL, time.Duration(o.ClusteradmFlags.Timeout)*time.Second)
+		if err != nil {
+			return "", nil, err
+		}
+
+		signals := make(chan os.Signal, 1)
+		done := make(chan struct{})
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			select {
+			case <-signals:
+				cleanup()
+				os.Exit(1)
+			case <-done:
+			}
+		}()
+		return token, func() {
+			signal.Stop(signals)
+			close(done)
+			cleanup()
+		}, nil
+	default:
+		token, err := clusterproxy.GetManagedServiceAccountToken(hubRestConfig, o.managedServiceAccount, o.ClusterOption.Cluster)
+		return token, func() {}, err
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	"open-cluster-management.io/clusteradm/pkg/helpers"
+)
+
+var example = `
+# Serve the kube-apiservers of cluster1 and cluster2 through cluster-proxy and write a kubeconfig with a context per cluster
+%[1]s proxy serve --cluster cluster1,cluster2 --sa test --output-kubeconfig /tmp/clusters.kubeconfig
+
+# Access cluster1 with kubectl while the command is running
+kubectl --kubeconfig /tmp/clusters.kubeconfig --context cluster1 get nodes
+`
+
+// NewCmd ...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "serve the managed clusters locally through cluster-proxy addon",
+		Long: "Keep the tunnels of cluster-proxy open and serve the kube-apiserver of each managed cluster on a local endpoint " +
+			"until interrupted. A kubeconfig with a context per managed cluster is written for other tools to use.",
+		Example:      fmt.Sprintf(example, helpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRun: func(c *cobra.Command, args []string) {
+			helpers.DryRunMessage(o.ClusteradmFlags.DryRun)
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			return o.run()
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&o.Clusters, "cluster", "c", []string{}, "Names of the managed clusters to serve")
+	cmd.Flags().StringVar(&o.ManagedServiceAccount, "sa", "", "The name of the managedServiceAccount in each cluster namespace")
+	cmd.Flags().StringVar(&o.OutputKubeconfig, "output-kubeconfig", "cluster-proxy.kubeconfig",
+		"The path of the kubeconfig to write, it must not exist and is removed when the command exits")
+	cmd.Flags().Int32Var(&o.LocalPort, "local-port", 0,
+		"The local port serving the first managed cluster, the next clusters are served on the following ports. "+
+			"Free ports are allocated if it is 0")
+	cmd.Flags().Int32Var(&o.ProxyServerPort, "proxy-server-port", 0,
+		"The local port forwarded to the proxy-server on the hub, a free port is allocated if it is 0")
+
+	return cmd
+}
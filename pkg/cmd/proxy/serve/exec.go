@@ -0,0 +1,190 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+
+	clusterv1 "open-cluster-management.io/api/client/cluster/clientset/versioned/typed/cluster/v1"
+	"open-cluster-management.io/clusteradm/pkg/helpers/clusterproxy"
+)
+
+// endpoint is the local endpoint serving the kube-apiserver of a managed cluster
+type endpoint struct {
+	cluster string
+	port    int32
+	token   string
+}
+
+func (o *Options) complete(_ *cobra.Command, _ []string) error {
+	// dedupe the clusters in the order of the flag, which the ports and the current context follow
+	seen := sets.New[string]()
+	var clusters []string
+	for _, cluster := range o.Clusters {
+		if seen.Has(cluster) {
+			continue
+		}
+		seen.Insert(cluster)
+		clusters = append(clusters, cluster)
+	}
+	o.Clusters = clusters
+	klog.V(1).InfoS("serve options:", "clusters", o.Clusters, "output-kubeconfig", o.OutputKubeconfig)
+	return nil
+}
+
+func (o *Options) validate() error {
+	if err := o.ClusteradmFlags.ValidateHub(); err != nil {
+		return err
+	}
+	if len(o.Clusters) == 0 {
+		return fmt.Errorf("--cluster must be set")
+	}
+	for _, cluster := range o.Clusters {
+		if len(cluster) == 0 {
+			return fmt.Errorf("--cluster cannot contain an empty value")
+		}
+	}
+	if len(o.ManagedServiceAccount) == 0 {
+		return fmt.Errorf("--sa must be set")
+	}
+	if len(o.OutputKubeconfig) == 0 {
+		return fmt.Errorf("--output-kubeconfig must be set")
+	}
+	// the kubeconfig is removed when the command exits, so an existing file is not overwritten
+	if _, err := os.Stat(o.OutputKubeconfig); err == nil {
+		return fmt.Errorf("--output-kubeconfig %s already exists", o.OutputKubeconfig)
+	}
+	if o.LocalPort < 0 || o.ProxyServerPort < 0 {
+		return fmt.Errorf("--local-port and --proxy-server-port must not be negative")
+	}
+	return nil
+}
+
+func (o *Options) run() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	hubRestConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return errors.Wrapf(err, "failed loading hub cluster's client config")
+	}
+
+	proxyConfig, err := clusterproxy.GetProxyConfig(hubRestConfig, o.Streams)
+	if err != nil {
+		return err
+	}
+	if proxyConfig == nil {
+		return nil
+	}
+
+	clusterClient, err := clusterv1.NewForConfig(hubRestConfig)
+	if err != nil {
+		return err
+	}
+	tokens := map[string]string{}
+	for _, cluster := range o.Clusters {
+		if _, err := clusterClient.ManagedClusters().Get(ctx, cluster, metav1.GetOptions{}); err != nil {
+			return err
+		}
+		tokens[cluster], err = clusterproxy.GetManagedServiceAccountToken(hubRestConfig, o.ManagedServiceAccount, cluster)
+		if err != nil {
+			return errors.Wrapf(err, "failed getting token of cluster %s", cluster)
+		}
+	}
+
+	proxyCertificates, err := clusterproxy.GetProxyCertificates(hubRestConfig, proxyConfig)
+	if err != nil {
+		return err
+	}
+
+	// a single port-forward to the proxy-server is shared by the clusters
+	proxyServerPort, portForwardClose, err := clusterproxy.ListenLocalProxy(ctx, hubRestConfig, proxyConfig, o.ProxyServerPort)
+	if err != nil {
+		return err
+	}
+	defer portForwardClose()
+
+	var endpoints []endpoint
+	for i, cluster := range o.Clusters {
+		hps, err := clusterproxy.NewHTTPProxyServer(ctx, cluster, proxyServerPort, proxyCertificates)
+		if err != nil {
+			return err
+		}
+		port := int32(0)
+		if o.LocalPort > 0 {
+			port = o.LocalPort + int32(i)
+		}
+		port, err = hps.Listen(ctx, port)
+		if err != nil {
+			return errors.Wrapf(err, "failed serving cluster %s", cluster)
+		}
+		endpoints = append(endpoints, endpoint{cluster: cluster, port: port, token: tokens[cluster]})
+	}
+
+	if err := writeKubeconfig(buildKubeconfig(endpoints), o.OutputKubeconfig); err != nil {
+		return errors.Wrapf(err, "failed writing kubeconfig %s", o.OutputKubeconfig)
+	}
+	// the kubeconfig contains the tokens and is useless without the endpoints
+	defer os.Remove(o.OutputKubeconfig)
+
+	for _, e := range endpoints {
+		fmt.Fprintf(o.Streams.Out, "Serving cluster %s on https://localhost:%d\n", e.cluster, e.port)
+	}
+	fmt.Fprintf(o.Streams.Out, "Kubeconfig is written to %s, press Ctrl+C to exit\n", o.OutputKubeconfig)
+
+	<-ctx.Done()
+	fmt.Fprintf(o.Streams.Out, "Stopped serving the clusters\n")
+	return nil
+}
+
+// writeKubeconfig writes the kubeconfig to a new file readable only by the user, the file must not exist.
+func writeKubeconfig(kubeconfig *clientcmdapi.Config, path string) error {
+	data, err := clientcmd.Write(*kubeconfig)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// buildKubeconfig returns a kubeconfig with a context named after each managed cluster, the current
+// context is the first cluster.
+func buildKubeconfig(endpoints []endpoint) *clientcmdapi.Config {
+	kubeconfig := clientcmdapi.NewConfig()
+	for _, e := range endpoints {
+		kubeconfig.Clusters[e.cluster] = &clientcmdapi.Cluster{
+			Server:                fmt.Sprintf("https://localhost:%d", e.port),
+			InsecureSkipTLSVerify: true, // Because we are using a local proxy
+		}
+		kubeconfig.AuthInfos[e.cluster] = &clientcmdapi.AuthInfo{
+			Token: e.token,
+		}
+		kubeconfig.Contexts[e.cluster] = &clientcmdapi.Context{
+			Cluster:  e.cluster,
+			AuthInfo: e.cluster,
+		}
+	}
+	if len(endpoints) > 0 {
+		kubeconfig.CurrentContext = endpoints[0].cluster
+	}
+	return kubeconfig
+}
@@ -0,0 +1,79 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuildKubeconfig(t *testing.T) {
+	kubeconfig := buildKubeconfig([]endpoint{
+		{cluster: "cluster1", port: 35001, token: "token1"},
+		{cluster: "cluster2", port: 35002, token: "token2"},
+	})
+
+	if kubeconfig.CurrentContext != "cluster1" {
+		t.Errorf("expected current context cluster1, got %s", kubeconfig.CurrentContext)
+	}
+	if len(kubeconfig.Contexts) != 2 {
+		t.Fatalf("expected 2 contexts, got %d", len(kubeconfig.Contexts))
+	}
+	for cluster, expected := range map[string]struct{ server, token string }{
+		"cluster1": {server: "https://localhost:35001", token: "token1"},
+		"cluster2": {server: "https://localhost:35002", token: "token2"},
+	} {
+		context, ok := kubeconfig.Contexts[cluster]
+		if !ok {
+			t.Fatalf("context %s is not found", cluster)
+		}
+		if server := kubeconfig.Clusters[context.Cluster].Server; server != expected.server {
+			t.Errorf("expected server %s of cluster %s, got %s", expected.server, cluster, server)
+		}
+		if token := kubeconfig.AuthInfos[context.AuthInfo].Token; token != expected.token {
+			t.Errorf("expected token %s of cluster %s, got %s", expected.token, cluster, token)
+		}
+	}
+}
+
+func TestCompleteKeepsClusterOrder(t *testing.T) {
+	o := &Options{Clusters: []string{"cluster3", "cluster1", "cluster3", "cluster2"}}
+	if err := o.complete(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(o.Clusters, []string{"cluster3", "cluster1", "cluster2"}) {
+		t.Errorf("expected the clusters deduped in the order of the flag, got %v", o.Clusters)
+	}
+}
+
+func TestWriteKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cluster-proxy.kubeconfig")
+	kubeconfig := buildKubeconfig([]endpoint{{cluster: "cluster1", port: 35001, token: "token1"}})
+
+	if err := writeKubeconfig(kubeconfig, path); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("expected the kubeconfig readable only by the user, got %v", mode)
+	}
+
+	// an existing file is not overwritten
+	if err := os.WriteFile(path, []byte("existing"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeKubeconfig(kubeconfig, path); err == nil {
+		t.Errorf("expected an error writing to an existing file")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "existing" {
+		t.Errorf("expected the existing file kept, got %s", data)
+	}
+}
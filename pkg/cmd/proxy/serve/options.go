@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+package serve
+
+import (
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+// Options is holding all the command-line options
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	//Clusters: the names of the managed clusters to serve
+	Clusters []string
+	//ManagedServiceAccount: the name of the managed service account used to access the clusters
+	ManagedServiceAccount string
+	//OutputKubeconfig: the path of the kubeconfig written with a context per cluster
+	OutputKubeconfig string
+	//LocalPort: the local port of the first cluster, the free ports are used if it is 0
+	LocalPort int32
+	//ProxyServerPort: the local port forwarded to the proxy-server, a free port is used if it is 0
+	ProxyServerPort int32
+
+	Streams genericiooptions.IOStreams
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+	}
+}
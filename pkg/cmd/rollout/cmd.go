@@ -0,0 +1,22 @@
+// Copyright Contributors to the Open Cluster Management project
+package rollout
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/cmd/rollout/status"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping the rollout subcommands
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout",
+		Short: "manage the rollout of works",
+	}
+
+	cmd.AddCommand(status.NewCmd(clusteradmFlags, streams))
+
+	return cmd
+}
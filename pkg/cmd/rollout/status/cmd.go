@@ -0,0 +1,22 @@
+// Copyright Contributors to the Open Cluster Management project
+package status
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/cmd/rollout/status/work"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping the rollout status subcommands
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "show the status of a rollout",
+	}
+
+	cmd.AddCommand(work.NewCmd(clusteradmFlags, streams))
+
+	return cmd
+}
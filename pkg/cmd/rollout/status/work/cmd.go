@@ -0,0 +1,55 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var example = `
+# Follow the rollout of the manifestworkreplicaset work1 in the default namespace until it completes or fails
+%[1]s rollout status work work1
+
+# Wait for at most 30 minutes
+%[1]s rollout status work work1 -n app --timeout 1800
+
+# Show the current status of the rollout without waiting
+%[1]s rollout status work work1 --watch=false
+`
+
+// NewCmd...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:   "work NAME",
+		Short: "show the rollout status of a manifestworkreplicaset",
+		Long: "follow the rollout of a manifestworkreplicaset until it completes. The command fails when more clusters " +
+			"fail than the max failures of the rollout strategy tolerate, or when it times out",
+		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "default", "The namespace of the manifestworkreplicaset")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", true, "Follow the rollout until it completes or fails")
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one manifestworkreplicaset must be specified")
+	}
+	o.name = args[0]
+	return nil
+}
+
+func (o *Options) validate() error {
+	return o.ClusteradmFlags.ValidateHub()
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	workClient, err := workclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.TODO()
+	if !o.Watch {
+		progress, err := o.progress(ctx, workClient)
+		if err != nil {
+			return err
+		}
+		return o.printProgress(progress)
+	}
+
+	var last string
+	timeout := time.Duration(o.ClusteradmFlags.Timeout) * time.Second
+	err = wait.PollUntilContextTimeout(ctx, o.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		progress, err := o.progress(ctx, workClient)
+		if err != nil {
+			return false, err
+		}
+		// the progress is only printed when it changes
+		if current := progress.String(); current != last || progress.Complete || progress.Breached() {
+			last = current
+			if err := o.printProgress(progress); err != nil {
+				return false, err
+			}
+		}
+		return progress.Complete, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timeout waiting for the rollout of manifestworkreplicaset %s/%s to complete: %s",
+			o.Namespace, o.name, last)
+	}
+	return err
+}
+
+func (o *Options) progress(ctx context.Context, workClient workclientset.Interface) (workhelpers.RolloutProgress, error) {
+	workSet, err := workClient.WorkV1alpha1().ManifestWorkReplicaSets(o.Namespace).Get(ctx, o.name, metav1.GetOptions{})
+	if err != nil {
+		return workhelpers.RolloutProgress{}, err
+	}
+	works, err := workhelpers.ReplicaSetWorks(ctx, workClient, o.Namespace, o.name)
+	if err != nil {
+		return workhelpers.RolloutProgress{}, err
+	}
+	return workhelpers.ReplicaSetProgress(workSet, works), nil
+}
+
+// printProgress prints the progress of the rollout, and returns an error if the failures are more than tolerated.
+func (o *Options) printProgress(progress workhelpers.RolloutProgress) error {
+	if progress.Breached() {
+		return fmt.Errorf("rollout of manifestworkreplicaset %s/%s failed: %s, more than %d tolerated, failed on clusters %s",
+			o.Namespace, o.name, progress, progress.MaxFailures, strings.Join(progress.Failed, ","))
+	}
+	if progress.Complete {
+		_, err := fmt.Fprintf(o.Streams.Out, "manifestworkreplicaset %s/%s successfully rolled out: %s\n", o.Namespace, o.name, progress)
+		return err
+	}
+	_, err := fmt.Fprintf(o.Streams.Out, "Waiting for rollout of manifestworkreplicaset %s/%s to finish: %s\n", o.Namespace, o.name, progress)
+	return err
+}
@@ -0,0 +1,36 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	Streams genericiooptions.IOStreams
+
+	// Namespace is the namespace of the manifestworkreplicaset
+	Namespace string
+
+	// Watch is to follow the rollout until it completes or fails
+	Watch bool
+
+	name string
+
+	// pollInterval is the interval to check the status of the manifestworkreplicaset
+	pollInterval time.Duration
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+		pollInterval:    2 * time.Second,
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var example = `
+# Add a taint to a cluster, placements not tolerating it do not select the cluster
+%[1]s taint cluster cluster1 gpu=false:NoSelect
+
+# Update the value of an existing taint
+%[1]s taint cluster cluster1 gpu=true:NoSelect --overwrite
+
+# Remove the taint with the key and effect from a cluster
+%[1]s taint cluster cluster1 gpu:NoSelect-
+
+# Remove all the taints with the key from a cluster
+%[1]s taint cluster cluster1 gpu-
+`
+
+// NewCmd...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:   "cluster NAME KEY_1[=VAL_1]:TAINT_EFFECT_1 ... KEY_N[=VAL_N]:TAINT_EFFECT_N",
+		Short: "update the taints of a managed cluster",
+		Long: "add or remove the taints of a managed cluster. The taint effect is one of NoSelect, PreferNoSelect " +
+			"and NoSelectIfNew, and a taint is removed with a trailing dash",
+		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&o.Overwrite, "overwrite", false, "If set, the value of an existing taint with the same key and effect is overwritten")
+
+	return cmd
+}
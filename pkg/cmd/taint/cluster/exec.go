@@ -0,0 +1,57 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterhelpers "open-cluster-management.io/clusteradm/pkg/helpers/cluster"
+)
+
+func (o *Options) complete(_ *cobra.Command, args []string) (err error) {
+	if len(args) < 2 {
+		return fmt.Errorf("the name of the cluster and at least one taint must be specified")
+	}
+	o.Cluster = args[0]
+
+	o.taintsToAdd, o.taintsToRemove, err = clusterhelpers.ParseTaints(args[1:])
+	return err
+}
+
+func (o *Options) validate() error {
+	return o.ClusteradmFlags.ValidateHub()
+}
+
+func (o *Options) run() error {
+	restConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	clusterClient, err := clusterclientset.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	cluster, changed, err := clusterhelpers.UpdateCluster(context.TODO(), clusterClient, o.Cluster, o.ClusteradmFlags.DryRun,
+		func(cluster *clusterv1.ManagedCluster) (bool, error) {
+			return clusterhelpers.ApplyTaints(cluster, o.taintsToAdd, o.taintsToRemove, o.Overwrite, time.Now())
+		})
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		fmt.Fprintf(o.Streams.Out, "Cluster %s is not changed\n", cluster.Name)
+		return nil
+	}
+	fmt.Fprintf(o.Streams.Out, "Taints of cluster %s are updated:\n", cluster.Name)
+	for _, taint := range cluster.Spec.Taints {
+		fmt.Fprintf(o.Streams.Out, "  %s\n", clusterhelpers.FormatTaint(taint))
+	}
+	return nil
+}
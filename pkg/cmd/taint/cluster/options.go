@@ -0,0 +1,31 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	Streams genericiooptions.IOStreams
+
+	Cluster string
+
+	// Overwrite allows to update the value of an existing taint
+	Overwrite bool
+
+	taintsToAdd    []clusterv1.Taint
+	taintsToRemove []clusterv1.Taint
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+	}
+}
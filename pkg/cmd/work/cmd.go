@@ -0,0 +1,22 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"open-cluster-management.io/clusteradm/pkg/cmd/work/drift"
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+)
+
+// NewCmd provides a cobra command wrapping the work subcommands
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "work",
+		Short: "inspect the works applied on managed clusters",
+	}
+
+	cmd.AddCommand(drift.NewCmd(clusteradmFlags, streams))
+
+	return cmd
+}
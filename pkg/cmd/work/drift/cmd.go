@@ -0,0 +1,67 @@
+// Copyright Contributors to the Open Cluster Management project
+package drift
+
+import (
+	"fmt"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	clusteradmhelpers "open-cluster-management.io/clusteradm/pkg/helpers"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+)
+
+var example = `
+# Compare the work web with the resources on cluster1 through cluster-proxy as the hub user
+%[1]s work drift --cluster cluster1 --work web --impersonate
+
+# Compare all the works on the clusters with a kubeconfig having a context per cluster, e.g. written by proxy serve
+%[1]s work drift --clusters cluster1,cluster2 --managed-cluster-kubeconfig clusters.kubeconfig
+
+# Compare the work with a managed service account, and bump the drifted works to be applied again
+%[1]s work drift --cluster cluster1 --work web --sa test --reapply
+`
+
+// NewCmd...
+func NewCmd(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *cobra.Command {
+	o := newOptions(clusteradmFlags, streams)
+
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "compare the works with the resources on the managed clusters",
+		Long: "compare the manifests of the works with the live resources on the managed clusters, read with a kubeconfig " +
+			"or through cluster-proxy. Only the fields in the manifests are compared, the fields not applied by the work " +
+			"agent, the fields ignored by server side apply and the resources created only or read only are not drifts",
+		Example:      fmt.Sprintf(example, clusteradmhelpers.GetExampleHeader()),
+		SilenceUsage: true,
+		PreRunE: func(c *cobra.Command, args []string) error {
+			clusteradmhelpers.DryRunMessage(clusteradmFlags.DryRun)
+
+			return nil
+		},
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.complete(c, args); err != nil {
+				return err
+			}
+			if err := o.validate(); err != nil {
+				return err
+			}
+			if err := o.run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.ClusterOption.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.Workname, "work", "", "The name of the work, all the works on the clusters if not set")
+	cmd.Flags().StringVar(&o.ManagedClusterKubeconfig, "managed-cluster-kubeconfig", "",
+		"The kubeconfig of the managed clusters, with a context named after each cluster or the current context for a single cluster")
+	cmd.Flags().StringVar(&o.ManagedServiceAccount, "sa", "", "The managed service account to access the managed clusters through cluster-proxy")
+	cmd.Flags().BoolVar(&o.Impersonate, "impersonate", false, "Access the managed clusters through cluster-proxy as the hub user")
+	cmd.Flags().BoolVar(&o.Reapply, "reapply", false, "Bump the drifted works, so the work agent applies them again")
+	o.printer.AddFlag(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,326 @@
+// Copyright Contributors to the Open Cluster Management project
+package drift
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+	"open-cluster-management.io/clusteradm/pkg/helpers/clusterproxy"
+	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+	workhelpers "open-cluster-management.io/clusteradm/pkg/helpers/work"
+)
+
+// resourceDrift is a drifted field of a resource in a work.
+type resourceDrift struct {
+	resource string
+	workhelpers.FieldDrift
+}
+
+func (o *Options) complete(_ *cobra.Command, _ []string) error {
+	o.printer.Competele()
+	return nil
+}
+
+func (o *Options) validate() error {
+	if err := o.ClusteradmFlags.ValidateHub(); err != nil {
+		return err
+	}
+	if err := o.ClusterOption.Validate(); err != nil {
+		return err
+	}
+	access := 0
+	for _, set := range []bool{len(o.ManagedClusterKubeconfig) > 0, len(o.ManagedServiceAccount) > 0, o.Impersonate} {
+		if set {
+			access++
+		}
+	}
+	if access != 1 {
+		return fmt.Errorf("exactly one of --managed-cluster-kubeconfig, --sa and --impersonate is required")
+	}
+	return o.printer.Validate()
+}
+
+func (o *Options) run() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hubRestConfig, err := o.ClusteradmFlags.KubectlFactory.ToRESTConfig()
+	if err != nil {
+		return err
+	}
+	workClient, err := workclientset.NewForConfig(hubRestConfig)
+	if err != nil {
+		return err
+	}
+
+	clusterConfig, closeFn, err := o.clusterConfigFunc(ctx, hubRestConfig)
+	if err != nil {
+		return err
+	}
+	if clusterConfig == nil {
+		return nil
+	}
+	defer closeFn()
+
+	workList := &workapiv1.ManifestWorkList{Items: []workapiv1.ManifestWork{}}
+	drifts := map[string][]resourceDrift{}
+	for _, cluster := range sets.List(o.ClusterOption.AllClusters()) {
+		works, err := o.works(ctx, workClient, cluster)
+		if err != nil {
+			return err
+		}
+		if len(works) == 0 {
+			continue
+		}
+		restConfig, err := clusterConfig(cluster)
+		if err != nil {
+			return err
+		}
+		for i := range works {
+			workDrifts, err := workDrifts(ctx, restConfig, &works[i])
+			if err != nil {
+				return fmt.Errorf("failed to compare work %s on cluster %s: %v", works[i].Name, cluster, err)
+			}
+			drifts[cluster+"/"+works[i].Name] = workDrifts
+			workList.Items = append(workList.Items, works[i])
+		}
+	}
+
+	o.printer.WithTreeConverter(func(obj runtime.Object, tree *printer.TreePrinter) *printer.TreePrinter {
+		return convertToTree(obj, tree, drifts)
+	}).WithTableConverter(func(obj runtime.Object) *metav1.Table {
+		return convertToTable(obj, drifts)
+	})
+	if err := o.printer.Print(o.Streams, workList); err != nil {
+		return err
+	}
+
+	if o.Reapply {
+		return o.reapply(ctx, workClient, workList, drifts)
+	}
+	return nil
+}
+
+// works returns the work of the name on the cluster, or all the works on the cluster if no name is specified.
+func (o *Options) works(ctx context.Context, workClient workclientset.Interface, cluster string) ([]workapiv1.ManifestWork, error) {
+	if len(o.Workname) == 0 {
+		works, err := workClient.WorkV1().ManifestWorks(cluster).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return works.Items, nil
+	}
+
+	work, err := workClient.WorkV1().ManifestWorks(cluster).Get(ctx, o.Workname, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		fmt.Fprintf(o.Streams.ErrOut, "work %s is not found on cluster %s\n", o.Workname, cluster)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []workapiv1.ManifestWork{*work}, nil
+}
+
+// clusterConfigFunc returns a func building the rest config of a managed cluster from the kubeconfig, or
+// through cluster-proxy, and a func to stop the port forwarding to cluster-proxy. A nil func is returned
+// if cluster-proxy is not installed.
+func (o *Options) clusterConfigFunc(ctx context.Context, hubRestConfig *rest.Config) (func(cluster string) (*rest.Config, error), func(), error) {
+	if len(o.ManagedClusterKubeconfig) > 0 {
+		single := o.ClusterOption.AllClusters().Len() == 1
+		return func(cluster string) (*rest.Config, error) {
+			return kubeconfigRESTConfig(o.ManagedClusterKubeconfig, cluster, single)
+		}, func() {}, nil
+	}
+
+	proxyConfig, err := clusterproxy.GetProxyConfig(hubRestConfig, o.Streams)
+	if err != nil || proxyConfig == nil {
+		return nil, nil, err
+	}
+	proxyCertificates, err := clusterproxy.GetProxyCertificates(hubRestConfig, proxyConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	proxyServerPort, closeFn, err := clusterproxy.ListenLocalProxy(ctx, hubRestConfig, proxyConfig, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return func(cluster string) (*rest.Config, error) {
+		var token string
+		var err error
+		if o.Impersonate {
+			token, err = clusterproxy.GetHubUserToken(hubRestConfig)
+		} else {
+			token, err = clusterproxy.GetManagedServiceAccountToken(hubRestConfig, o.ManagedServiceAccount, cluster)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return clusterproxy.NewClusterRESTConfig(ctx, cluster, proxyServerPort, proxyCertificates, token)
+	}, closeFn, nil
+}
+
+// kubeconfigRESTConfig returns the rest config of the context named after the cluster in the kubeconfig,
+// or the current context if it is the only cluster.
+func kubeconfigRESTConfig(path, cluster string, single bool) (*rest.Config, error) {
+	rules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: path}
+	rawConfig, err := rules.Load()
+	if err != nil {
+		return nil, err
+	}
+	overrides := &clientcmd.ConfigOverrides{}
+	if _, ok := rawConfig.Contexts[cluster]; ok {
+		overrides.CurrentContext = cluster
+	} else if !single {
+		return nil, fmt.Errorf("context %s is not found in kubeconfig %s", cluster, path)
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+}
+
+// workDrifts compares the manifests of the work with the live resources on the managed cluster.
+func workDrifts(ctx context.Context, restConfig *rest.Config, work *workapiv1.ManifestWork) ([]resourceDrift, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []resourceDrift
+	for _, manifest := range work.Spec.Workload.Manifests {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			return nil, err
+		}
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		id := workapiv1.ResourceIdentifier{
+			Group:     mapping.Resource.Group,
+			Resource:  mapping.Resource.Resource,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		}
+		var config *workapiv1.ManifestConfigOption
+		for i := range work.Spec.ManifestConfigs {
+			if work.Spec.ManifestConfigs[i].ResourceIdentifier == id {
+				config = &work.Spec.ManifestConfigs[i]
+			}
+		}
+		if !workhelpers.EnforcedByAgent(config) {
+			continue
+		}
+
+		var resourceClient dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			resourceClient = dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+		}
+		live, err := resourceClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			live = nil
+		case err != nil:
+			return nil, err
+		}
+
+		for _, drift := range workhelpers.Drifts(obj, live, workhelpers.IgnoredFields(config)) {
+			drifts = append(drifts, resourceDrift{resource: workhelpers.FormatResourceIdentifier(id), FieldDrift: drift})
+		}
+	}
+	return drifts, nil
+}
+
+// reapply bumps the annotation of the drifted works, so the work agent applies the manifests again.
+func (o *Options) reapply(ctx context.Context, workClient workclientset.Interface,
+	workList *workapiv1.ManifestWorkList, drifts map[string][]resourceDrift) error {
+	for _, work := range workList.Items {
+		if len(drifts[work.Namespace+"/"+work.Name]) == 0 {
+			continue
+		}
+		if o.ClusteradmFlags.DryRun {
+			fmt.Fprintf(o.Streams.Out, "work %s on cluster %s would be reapplied\n", work.Name, work.Namespace)
+			continue
+		}
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, workhelpers.ReapplyAnnotation, time.Now().UTC().Format(time.RFC3339))
+		if _, err := workClient.WorkV1().ManifestWorks(work.Namespace).Patch(
+			ctx, work.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.Streams.Out, "work %s on cluster %s is bumped to be reapplied\n", work.Name, work.Namespace)
+	}
+	return nil
+}
+
+func convertToTree(obj runtime.Object, tree *printer.TreePrinter, drifts map[string][]resourceDrift) *printer.TreePrinter {
+	if workList, ok := obj.(*workapiv1.ManifestWorkList); ok {
+		for _, work := range workList.Items {
+			workDrifts := drifts[work.Namespace+"/"+work.Name]
+			mp := map[string]interface{}{
+				".Drifted": len(workDrifts) > 0,
+			}
+			for _, drift := range workDrifts {
+				field := drift.Field
+				if len(field) == 0 {
+					field = "/"
+				}
+				mp[fmt.Sprintf(".Drifts.%s.%s", drift.resource, field)] =
+					fmt.Sprintf("expected %s, actual %s", drift.Expected, drift.Actual)
+			}
+			tree.AddFileds(fmt.Sprintf("%s.%s", work.Namespace, work.Name), &mp)
+		}
+	}
+	return tree
+}
+
+func convertToTable(obj runtime.Object, drifts map[string][]resourceDrift) *metav1.Table {
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Cluster", Type: "string"},
+			{Name: "Work", Type: "string"},
+			{Name: "Resource", Type: "string"},
+			{Name: "Field", Type: "string"},
+			{Name: "Expected", Type: "string"},
+			{Name: "Actual", Type: "string"},
+		},
+		Rows: []metav1.TableRow{},
+	}
+
+	if workList, ok := obj.(*workapiv1.ManifestWorkList); ok {
+		for i := range workList.Items {
+			work := &workList.Items[i]
+			for _, drift := range drifts[work.Namespace+"/"+work.Name] {
+				table.Rows = append(table.Rows, metav1.TableRow{
+					Cells:  []interface{}{work.Namespace, work.Name, drift.resource, drift.Field, drift.Expected, drift.Actual},
+					Object: runtime.RawExtension{Object: work},
+				})
+			}
+		}
+	}
+
+	return table
+}
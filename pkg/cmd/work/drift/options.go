@@ -0,0 +1,62 @@
+// Copyright Contributors to the Open Cluster Management project
+package drift
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	genericclioptionsclusteradm "open-cluster-management.io/clusteradm/pkg/genericclioptions"
+	"open-cluster-management.io/clusteradm/pkg/helpers/printer"
+)
+
+type Options struct {
+	//ClusteradmFlags: The generic options from the clusteradm cli-runtime.
+	ClusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags
+
+	ClusterOption *genericclioptionsclusteradm.ClusterOption
+
+	Streams genericiooptions.IOStreams
+
+	printer *printer.PrinterOption
+
+	// Workname is the work to check, all the works on the clusters are checked if it is empty
+	Workname string
+
+	// ManagedClusterKubeconfig is the kubeconfig file to access the managed clusters, with a context named
+	// after each cluster, or the current context for a single cluster
+	ManagedClusterKubeconfig string
+
+	// ManagedServiceAccount is the managed service account to access the managed clusters through cluster-proxy
+	ManagedServiceAccount string
+
+	// Impersonate accesses the managed clusters through cluster-proxy as the hub user
+	Impersonate bool
+
+	// Reapply bumps the drifted works to trigger the work agent to apply them again
+	Reapply bool
+}
+
+func newOptions(clusteradmFlags *genericclioptionsclusteradm.ClusteradmFlags, streams genericiooptions.IOStreams) *Options {
+	return &Options{
+		ClusteradmFlags: clusteradmFlags,
+		Streams:         streams,
+		ClusterOption:   genericclioptionsclusteradm.NewClusterOption(),
+		printer:         printer.NewPrinterOption(pntOpt).WithDefaultFormat("table"),
+	}
+}
+
+var pntOpt = printers.PrintOptions{
+	NoHeaders:     false,
+	WithNamespace: false,
+	WithKind:      false,
+	Wide:          false,
+	ShowLabels:    false,
+	Kind: schema.GroupKind{
+		Group: "work.open-cluster-management.io",
+		Kind:  "ManifestWork",
+	},
+	ColumnLabels:     []string{},
+	SortBy:           "",
+	AllowMissingKeys: true,
+}
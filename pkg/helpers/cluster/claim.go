@@ -0,0 +1,156 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+
+	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+// ClaimWorkName is the name of the ManifestWork in the cluster namespace which delivers the ClusterClaims
+// to the managed cluster. The claims are reported back in the status of the ManagedCluster by the agent.
+const ClaimWorkName = "clusteradm-cluster-claims"
+
+// IsReservedClaim returns true if the claim is reported by the registration agent, or in the OCM domain.
+func IsReservedClaim(name string) bool {
+	for _, reserved := range clusterv1alpha1.ReservedClusterClaimNames {
+		if name == reserved {
+			return true
+		}
+	}
+	return IsReservedKey(name)
+}
+
+// UpdateClaims sets and removes the ClusterClaims in the ManifestWork of the claims of the cluster. A claim
+// already reported by the cluster with a different value is changed only if overwrite is true. The
+// ManifestWork is deleted if it has no claims left. It returns false if the claims are not changed.
+func UpdateClaims(
+	ctx context.Context,
+	workClient workclientset.Interface,
+	cluster *clusterv1.ManagedCluster,
+	set map[string]string,
+	remove []string,
+	overwrite, dryRun bool) (bool, error) {
+	reported := map[string]string{}
+	for _, claim := range cluster.Status.ClusterClaims {
+		reported[claim.Name] = claim.Value
+	}
+
+	changed := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		work, err := workClient.WorkV1().ManifestWorks(cluster.Name).Get(ctx, ClaimWorkName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			work = nil
+		case err != nil:
+			return err
+		}
+
+		claims, err := claimsOf(work)
+		if err != nil {
+			return err
+		}
+		changed = false
+		for name, value := range set {
+			existing, ok := claims[name]
+			if !ok {
+				existing, ok = reported[name]
+			}
+			if ok && existing == value {
+				continue
+			}
+			if ok && !overwrite {
+				return fmt.Errorf("cluster %s already has claim %s=%s, set --overwrite to change it", cluster.Name, name, existing)
+			}
+			claims[name] = value
+			changed = true
+		}
+		for _, name := range remove {
+			if _, ok := claims[name]; ok {
+				delete(claims, name)
+				changed = true
+			} else if _, ok := reported[name]; ok {
+				return fmt.Errorf("claim %s of cluster %s is not created by clusteradm, remove the ClusterClaim on the managed cluster instead",
+					name, cluster.Name)
+			}
+		}
+		if !changed || dryRun {
+			return nil
+		}
+
+		switch {
+		case len(claims) == 0 && work != nil:
+			return workClient.WorkV1().ManifestWorks(cluster.Name).Delete(ctx, ClaimWorkName, metav1.DeleteOptions{})
+		case len(claims) == 0:
+			return nil
+		case work == nil:
+			_, err = workClient.WorkV1().ManifestWorks(cluster.Name).Create(ctx, newClaimWork(cluster.Name, claims), metav1.CreateOptions{})
+			return err
+		default:
+			work.Spec.Workload.Manifests = newClaimWork(cluster.Name, claims).Spec.Workload.Manifests
+			_, err = workClient.WorkV1().ManifestWorks(cluster.Name).Update(ctx, work, metav1.UpdateOptions{})
+			return err
+		}
+	})
+	return changed, err
+}
+
+// claimsOf returns the name and value of the ClusterClaims in the ManifestWork.
+func claimsOf(work *workapiv1.ManifestWork) (map[string]string, error) {
+	claims := map[string]string{}
+	if work == nil {
+		return claims, nil
+	}
+	for _, manifest := range work.Spec.Workload.Manifests {
+		claim := &clusterv1alpha1.ClusterClaim{}
+		if err := json.Unmarshal(manifest.Raw, claim); err != nil {
+			return nil, fmt.Errorf("failed to decode the claims in manifestwork %s/%s: %v", work.Namespace, work.Name, err)
+		}
+		claims[claim.Name] = claim.Spec.Value
+	}
+	return claims, nil
+}
+
+func newClaimWork(namespace string, claims map[string]string) *workapiv1.ManifestWork {
+	names := make([]string, 0, len(claims))
+	for name := range claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	work := &workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ClaimWorkName,
+			Namespace: namespace,
+		},
+	}
+	for _, name := range names {
+		claim := &clusterv1alpha1.ClusterClaim{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: clusterv1alpha1.GroupVersion.String(),
+				Kind:       "ClusterClaim",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: clusterv1alpha1.ClusterClaimSpec{
+				Value: claims[name],
+			},
+		}
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, workapiv1.Manifest{
+			RawExtension: runtime.RawExtension{Object: claim},
+		})
+	}
+	return work
+}
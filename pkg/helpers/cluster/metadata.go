@@ -0,0 +1,207 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+)
+
+const (
+	FieldLabels      = "labels"
+	FieldAnnotations = "annotations"
+
+	// reservedDomain is the domain of the labels, annotations and claims maintained by OCM
+	reservedDomain = "open-cluster-management.io"
+)
+
+// IsReservedKey returns true if the key of a label, annotation or claim is maintained by OCM, like
+// cluster.open-cluster-management.io/clusterset, or the name label set by the registration controller.
+func IsReservedKey(key string) bool {
+	if key == "name" {
+		return true
+	}
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		// a claim name is not a label key, and the domain is the whole name
+		prefix = key
+	}
+	return prefix == reservedDomain || strings.HasSuffix(prefix, "."+reservedDomain)
+}
+
+// ParseKeyValues parses the key/values to set in the format of {key}={value}, and the keys to remove
+// in the format of {key}-. The keys and values are validated as labels if isLabel is true.
+func ParseKeyValues(specs []string, isLabel bool) (map[string]string, []string, error) {
+	set := map[string]string{}
+	var remove []string
+	for _, spec := range specs {
+		if strings.HasSuffix(spec, "-") {
+			key := strings.TrimSuffix(spec, "-")
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return nil, nil, fmt.Errorf("invalid key %s: %s", spec, strings.Join(errs, "; "))
+			}
+			remove = append(remove, key)
+			continue
+		}
+
+		key, value, found := strings.Cut(spec, "=")
+		if !found {
+			return nil, nil, fmt.Errorf("%s format is not correct, should be {key}={value} or {key}-", spec)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid key %s: %s", spec, strings.Join(errs, "; "))
+		}
+		if isLabel {
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return nil, nil, fmt.Errorf("invalid value %s: %s", spec, strings.Join(errs, "; "))
+			}
+		}
+		if _, ok := set[key]; ok {
+			return nil, nil, fmt.Errorf("key %s is specified more than once", key)
+		}
+		set[key] = value
+	}
+	for _, key := range remove {
+		if _, ok := set[key]; ok {
+			return nil, nil, fmt.Errorf("key %s can not be both set and removed", key)
+		}
+	}
+	return set, remove, nil
+}
+
+// SplitArgs splits the arguments into the names of the clusters and the {key}={value} or {key}- specs.
+func SplitArgs(args []string) (names, specs []string) {
+	for _, arg := range args {
+		if strings.Contains(arg, "=") || strings.HasSuffix(arg, "-") {
+			specs = append(specs, arg)
+			continue
+		}
+		names = append(names, arg)
+	}
+	return names, specs
+}
+
+// CheckReservedKeys returns an error if any of the keys is reserved.
+func CheckReservedKeys(set map[string]string, remove []string, isReserved func(key string) bool) error {
+	var reserved []string
+	for key := range set {
+		if isReserved(key) {
+			reserved = append(reserved, key)
+		}
+	}
+	for _, key := range remove {
+		if isReserved(key) {
+			reserved = append(reserved, key)
+		}
+	}
+	if len(reserved) == 0 {
+		return nil
+	}
+	sort.Strings(reserved)
+	return fmt.Errorf("the keys %s are reserved by OCM, set --force to change them anyway", strings.Join(reserved, ","))
+}
+
+// SelectClusters returns the names of the clusters, or all the clusters in the clusterset if clusterSet
+// is set, or all the clusters if all is true.
+func SelectClusters(ctx context.Context, client clusterclientset.Interface, names []string, all bool, clusterSet string) ([]string, error) {
+	if len(names) > 0 {
+		return names, nil
+	}
+	if !all && len(clusterSet) == 0 {
+		return nil, fmt.Errorf("the clusters must be specified by names, --clusterset or --all")
+	}
+
+	options := metav1.ListOptions{}
+	if len(clusterSet) > 0 {
+		options.LabelSelector = fmt.Sprintf("%s=%s", clusterv1beta2.ClusterSetLabel, clusterSet)
+	}
+	clusters, err := client.ClusterV1().ManagedClusters().List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	var selected []string
+	for _, cluster := range clusters.Items {
+		selected = append(selected, cluster.Name)
+	}
+	sort.Strings(selected)
+	return selected, nil
+}
+
+// PatchMetadata sets and removes the labels or annotations of the cluster with a merge patch. The patch
+// has the resourceVersion of the cluster, so it fails on conflicts and is retried with the latest cluster.
+// An existing key with a different value is changed only if overwrite is true. It returns false if the
+// cluster is not changed. The cluster is not patched if dryRun is true.
+func PatchMetadata(
+	ctx context.Context,
+	client clusterclientset.Interface,
+	name, field string,
+	set map[string]string,
+	remove []string,
+	overwrite, dryRun bool) (bool, error) {
+	changed := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := client.ClusterV1().ManagedClusters().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		patch, err := metadataPatch(cluster, field, set, remove, overwrite)
+		if err != nil {
+			return err
+		}
+		changed = patch != nil
+		if patch == nil || dryRun {
+			return nil
+		}
+		_, err = client.ClusterV1().ManagedClusters().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	})
+	return changed, err
+}
+
+// metadataPatch returns the merge patch of the labels or annotations of the cluster, or nil if nothing
+// is changed.
+func metadataPatch(cluster *clusterv1.ManagedCluster, field string, set map[string]string, remove []string, overwrite bool) ([]byte, error) {
+	current := cluster.Labels
+	if field == FieldAnnotations {
+		current = cluster.Annotations
+	}
+
+	changes := map[string]interface{}{}
+	for key, value := range set {
+		existing, ok := current[key]
+		if ok && existing == value {
+			continue
+		}
+		if ok && !overwrite {
+			return nil, fmt.Errorf("cluster %s already has %s %s=%s, set --overwrite to change it", cluster.Name, field, key, existing)
+		}
+		changes[key] = value
+	}
+	for _, key := range remove {
+		if _, ok := current[key]; ok {
+			// a null value removes the key in a merge patch
+			changes[key] = nil
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": cluster.ResourceVersion,
+			field:             changes,
+		},
+	})
+}
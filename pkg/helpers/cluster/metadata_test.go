@@ -0,0 +1,87 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func TestIsReservedKey(t *testing.T) {
+	cases := map[string]bool{
+		"name": true,
+		"cluster.open-cluster-management.io/clusterset": true,
+		"feature.open-cluster-management.io/addon-foo":  true,
+		"open-cluster-management.io/cluster-name":       true,
+		"platform.open-cluster-management.io":           true,
+		"env":                                           false,
+		"example.com/open-cluster-management.io":        false,
+		"fake-open-cluster-management.io/clusterset":    false,
+		"region.example.com":                            false,
+	}
+	for key, expected := range cases {
+		if actual := IsReservedKey(key); actual != expected {
+			t.Errorf("expected %s reserved %t, got %t", key, expected, actual)
+		}
+	}
+}
+
+func TestMetadataPatch(t *testing.T) {
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cluster1",
+			ResourceVersion: "10",
+			Labels:          map[string]string{"env": "dev", "team": "a"},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		specs       []string
+		overwrite   bool
+		expectErr   bool
+		expectPatch string
+	}{
+		{
+			name:        "add and remove labels",
+			specs:       []string{"region=us", "team-"},
+			expectPatch: `{"metadata":{"labels":{"region":"us","team":null},"resourceVersion":"10"}}`,
+		},
+		{
+			name:  "no changes",
+			specs: []string{"env=dev", "foo-"},
+		},
+		{
+			name:      "change a label without overwrite",
+			specs:     []string{"env=prod"},
+			expectErr: true,
+		},
+		{
+			name:        "change a label with overwrite",
+			specs:       []string{"env=prod"},
+			overwrite:   true,
+			expectPatch: `{"metadata":{"labels":{"env":"prod"},"resourceVersion":"10"}}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			set, remove, err := ParseKeyValues(c.specs, true)
+			if err != nil {
+				t.Fatalf("should not have error, but got %v", err)
+			}
+			patch, err := metadataPatch(cluster, FieldLabels, set, remove, c.overwrite)
+			if err != nil && !c.expectErr {
+				t.Errorf("should not have error, but got %v", err)
+			}
+			if err == nil && c.expectErr {
+				t.Errorf("should return err")
+			}
+			if string(patch) != c.expectPatch {
+				t.Errorf("expected patch %s, got %s", c.expectPatch, string(patch))
+			}
+		})
+	}
+}
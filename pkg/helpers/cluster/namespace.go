@@ -0,0 +1,188 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	clustersdkv1beta2 "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta2"
+)
+
+// The states of a managed namespace on a member cluster of the clusterset.
+const (
+	// NamespaceAvailable means all the conditions of the managed namespace reported by the cluster are True
+	NamespaceAvailable = "Available"
+	// NamespaceFailed means a condition of the managed namespace reported by the cluster is False
+	NamespaceFailed = "Failed"
+	// NamespacePending means the cluster does not report the managed namespace, or its conditions, yet
+	NamespacePending = "Pending"
+)
+
+// NamespaceStatus is the status of a managed namespace of a clusterset on a member cluster.
+type NamespaceStatus struct {
+	Namespace string
+	Cluster   string
+	State     string
+	Reason    string
+	Message   string
+}
+
+// ValidateNamespaceNames trims and deduplicates the names of the namespaces, and returns an error if any
+// of them is invalid.
+func ValidateNamespaceNames(namespaces []string) ([]string, error) {
+	var names []string
+	for _, namespace := range namespaces {
+		namespace = strings.TrimSpace(namespace)
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, "; "))
+		}
+		if slices.Contains(names, namespace) {
+			continue
+		}
+		names = append(names, namespace)
+	}
+	return names, nil
+}
+
+// UpdateManagedNamespaces adds and removes the managed namespaces of the clusterset, and retries on
+// conflicts. It returns the namespaces actually added and removed, and the clusterset is not updated if
+// nothing is changed or dryRun is true. It is an error to remove a namespace which is not managed.
+func UpdateManagedNamespaces(
+	ctx context.Context,
+	client clusterclientset.Interface,
+	name string,
+	add, remove []string,
+	dryRun bool) (*clusterv1beta2.ManagedClusterSet, []string, []string, error) {
+	var clusterSet *clusterv1beta2.ManagedClusterSet
+	var added, removed []string
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		clusterSet, err = client.ClusterV1beta2().ManagedClusterSets().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		added, removed, err = applyManagedNamespaces(clusterSet, add, remove)
+		if err != nil || (len(added) == 0 && len(removed) == 0) || dryRun {
+			return err
+		}
+		clusterSet, err = client.ClusterV1beta2().ManagedClusterSets().Update(ctx, clusterSet, metav1.UpdateOptions{})
+		return err
+	})
+	return clusterSet, added, removed, err
+}
+
+// applyManagedNamespaces adds and removes the managed namespaces in the spec of the clusterset, and returns
+// the namespaces actually added and removed.
+func applyManagedNamespaces(clusterSet *clusterv1beta2.ManagedClusterSet, add, remove []string) ([]string, []string, error) {
+	existing := map[string]bool{}
+	for _, ns := range clusterSet.Spec.ManagedNamespaces {
+		existing[ns.Name] = true
+	}
+
+	var removed []string
+	for _, namespace := range remove {
+		if !existing[namespace] {
+			return nil, nil, fmt.Errorf("namespace %s is not managed by clusterset %s", namespace, clusterSet.Name)
+		}
+		delete(existing, namespace)
+		removed = append(removed, namespace)
+	}
+	if len(removed) > 0 {
+		var namespaces []clusterv1.ManagedNamespaceConfig
+		for _, ns := range clusterSet.Spec.ManagedNamespaces {
+			if existing[ns.Name] {
+				namespaces = append(namespaces, ns)
+			}
+		}
+		clusterSet.Spec.ManagedNamespaces = namespaces
+	}
+
+	var added []string
+	for _, namespace := range add {
+		if existing[namespace] {
+			continue
+		}
+		existing[namespace] = true
+		clusterSet.Spec.ManagedNamespaces = append(clusterSet.Spec.ManagedNamespaces, clusterv1.ManagedNamespaceConfig{Name: namespace})
+		added = append(added, namespace)
+	}
+	return added, removed, nil
+}
+
+// ClustersOfClusterSet returns the member clusters of the clusterset, with either the clusterset label or
+// the label selector, sorted by name.
+func ClustersOfClusterSet(ctx context.Context, client clusterclientset.Interface, clusterSet *clusterv1beta2.ManagedClusterSet) ([]*clusterv1.ManagedCluster, error) {
+	clusters, err := clustersdkv1beta2.GetClustersFromClusterSet(clusterSet, &clusterLister{ctx: ctx, client: client})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+	return clusters, nil
+}
+
+type clusterLister struct {
+	ctx    context.Context
+	client clusterclientset.Interface
+}
+
+func (l *clusterLister) List(selector labels.Selector) ([]*clusterv1.ManagedCluster, error) {
+	clusters, err := l.client.ClusterV1().ManagedClusters().List(l.ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	var ret []*clusterv1.ManagedCluster
+	for i := range clusters.Items {
+		ret = append(ret, &clusters.Items[i])
+	}
+	return ret, nil
+}
+
+// ManagedNamespaceStatuses returns the status of each managed namespace of the clusterset on each cluster,
+// by the managed namespaces reported in the status of the clusters. It is ordered by namespace, then cluster.
+func ManagedNamespaceStatuses(clusterSet string, namespaces []string, clusters []*clusterv1.ManagedCluster) []NamespaceStatus {
+	var statuses []NamespaceStatus
+	for _, namespace := range namespaces {
+		for _, cluster := range clusters {
+			status := NamespaceStatus{Namespace: namespace, Cluster: cluster.Name, State: NamespacePending}
+			for _, ns := range cluster.Status.ManagedNamespaces {
+				if ns.ClusterSet != clusterSet || ns.Name != namespace {
+					continue
+				}
+				status.State, status.Reason, status.Message = namespaceState(ns.Conditions)
+				break
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// namespaceState returns the state of a managed namespace by its conditions, with the reason and message
+// of the condition deciding it.
+func namespaceState(conditions []metav1.Condition) (string, string, string) {
+	if len(conditions) == 0 {
+		return NamespacePending, "", "the namespace is not reported with conditions yet"
+	}
+	for _, cond := range conditions {
+		if cond.Status == metav1.ConditionFalse {
+			return NamespaceFailed, cond.Reason, cond.Message
+		}
+	}
+	for _, cond := range conditions {
+		if cond.Status != metav1.ConditionTrue {
+			return NamespacePending, cond.Reason, cond.Message
+		}
+	}
+	return NamespaceAvailable, conditions[0].Reason, conditions[0].Message
+}
@@ -0,0 +1,100 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+)
+
+func TestApplyManagedNamespaces(t *testing.T) {
+	cases := []struct {
+		name          string
+		add           []string
+		remove        []string
+		expectErr     bool
+		expectAdded   []string
+		expectRemoved []string
+		expectSpec    []string
+	}{
+		{
+			name:        "add namespaces",
+			add:         []string{"ns1", "ns3"},
+			expectAdded: []string{"ns3"},
+			expectSpec:  []string{"ns1", "ns2", "ns3"},
+		},
+		{
+			name:          "remove namespaces",
+			remove:        []string{"ns1"},
+			expectRemoved: []string{"ns1"},
+			expectSpec:    []string{"ns2"},
+		},
+		{
+			name:      "remove a namespace not managed",
+			remove:    []string{"ns3"},
+			expectErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clusterSet := &clusterv1beta2.ManagedClusterSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "set1"},
+				Spec: clusterv1beta2.ManagedClusterSetSpec{
+					ManagedNamespaces: []clusterv1.ManagedNamespaceConfig{{Name: "ns1"}, {Name: "ns2"}},
+				},
+			}
+			added, removed, err := applyManagedNamespaces(clusterSet, c.add, c.remove)
+			if c.expectErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", c.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(added, c.expectAdded) || !reflect.DeepEqual(removed, c.expectRemoved) {
+				t.Errorf("expected added %v and removed %v, got %v and %v", c.expectAdded, c.expectRemoved, added, removed)
+			}
+			var spec []string
+			for _, ns := range clusterSet.Spec.ManagedNamespaces {
+				spec = append(spec, ns.Name)
+			}
+			if !reflect.DeepEqual(spec, c.expectSpec) {
+				t.Errorf("expected managed namespaces %v, got %v", c.expectSpec, spec)
+			}
+		})
+	}
+}
+
+func TestManagedNamespaceStatuses(t *testing.T) {
+	newCluster := func(name string, namespaces ...clusterv1.ClusterSetManagedNamespaceConfig) *clusterv1.ManagedCluster {
+		return &clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     clusterv1.ManagedClusterStatus{ManagedNamespaces: namespaces},
+		}
+	}
+	managedNamespace := func(clusterSet, name string, status metav1.ConditionStatus) clusterv1.ClusterSetManagedNamespaceConfig {
+		return clusterv1.ClusterSetManagedNamespaceConfig{
+			ManagedNamespaceConfig: clusterv1.ManagedNamespaceConfig{Name: name},
+			ClusterSet:             clusterSet,
+			Conditions:             []metav1.Condition{{Type: "NamespaceAvailable", Status: status, Reason: "Reason" + string(status)}},
+		}
+	}
+
+	clusters := []*clusterv1.ManagedCluster{
+		newCluster("cluster1", managedNamespace("set1", "ns1", metav1.ConditionTrue)),
+		newCluster("cluster2", managedNamespace("set1", "ns1", metav1.ConditionFalse)),
+		// the namespace of another clusterset is not the managed namespace of set1
+		newCluster("cluster3", managedNamespace("set2", "ns1", metav1.ConditionTrue)),
+	}
+	expected := []NamespaceStatus{
+		{Namespace: "ns1", Cluster: "cluster1", State: NamespaceAvailable, Reason: "ReasonTrue"},
+		{Namespace: "ns1", Cluster: "cluster2", State: NamespaceFailed, Reason: "ReasonFalse"},
+		{Namespace: "ns1", Cluster: "cluster3", State: NamespacePending},
+	}
+	if actual := ManagedNamespaceStatuses("set1", []string{"ns1"}, clusters); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+// CordonTaintKey is the key of the NoSelect taint added to a managed cluster by cordon, so placements
+// not tolerating it stop selecting the cluster.
+const CordonTaintKey = "cluster.open-cluster-management.io/unschedulable"
+
+var taintEffects = []clusterv1.TaintEffect{
+	clusterv1.TaintEffectNoSelect,
+	clusterv1.TaintEffectPreferNoSelect,
+	clusterv1.TaintEffectNoSelectIfNew,
+}
+
+// ParseTaints parses the taints to add in the format of {key}[={value}]:{effect}, and the taints to
+// remove in the format of {key}[:{effect}]-. A taint to remove without effect matches all the effects.
+func ParseTaints(specs []string) (add []clusterv1.Taint, remove []clusterv1.Taint, err error) {
+	for _, spec := range specs {
+		if strings.HasSuffix(spec, "-") {
+			key, effect, _ := strings.Cut(strings.TrimSuffix(spec, "-"), ":")
+			taint := clusterv1.Taint{Key: key, Effect: clusterv1.TaintEffect(effect)}
+			if err := validateTaint(spec, taint, true); err != nil {
+				return nil, nil, err
+			}
+			remove = append(remove, taint)
+			continue
+		}
+
+		keyValue, effect, found := strings.Cut(spec, ":")
+		if !found {
+			return nil, nil, fmt.Errorf("taint %s format is not correct, should be {key}[={value}]:{effect}", spec)
+		}
+		key, value, _ := strings.Cut(keyValue, "=")
+		taint := clusterv1.Taint{Key: key, Value: value, Effect: clusterv1.TaintEffect(effect)}
+		if err := validateTaint(spec, taint, false); err != nil {
+			return nil, nil, err
+		}
+		for _, t := range add {
+			if t.Key == taint.Key && t.Effect == taint.Effect {
+				return nil, nil, fmt.Errorf("taint %s is duplicated", spec)
+			}
+		}
+		add = append(add, taint)
+	}
+	return add, remove, nil
+}
+
+func validateTaint(spec string, taint clusterv1.Taint, remove bool) error {
+	if errs := validation.IsQualifiedName(taint.Key); len(errs) > 0 {
+		return fmt.Errorf("invalid key of taint %s: %s", spec, strings.Join(errs, "; "))
+	}
+	if len(taint.Value) > 0 {
+		if errs := validation.IsValidLabelValue(taint.Value); len(errs) > 0 {
+			return fmt.Errorf("invalid value of taint %s: %s", spec, strings.Join(errs, "; "))
+		}
+	}
+	if remove && len(taint.Effect) == 0 {
+		return nil
+	}
+	for _, effect := range taintEffects {
+		if taint.Effect == effect {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid effect of taint %s, should be one of NoSelect, PreferNoSelect and NoSelectIfNew", spec)
+}
+
+// ApplyTaints adds and removes the taints of the cluster, and returns true if the taints are changed.
+// An existing taint with the same key and effect is updated only if overwrite is true, and it is an
+// error to remove a taint which does not exist.
+func ApplyTaints(cluster *clusterv1.ManagedCluster, add, remove []clusterv1.Taint, overwrite bool, now time.Time) (bool, error) {
+	changed := false
+	for _, r := range remove {
+		var taints []clusterv1.Taint
+		for _, t := range cluster.Spec.Taints {
+			if t.Key == r.Key && (len(r.Effect) == 0 || t.Effect == r.Effect) {
+				continue
+			}
+			taints = append(taints, t)
+		}
+		if len(taints) == len(cluster.Spec.Taints) {
+			return false, fmt.Errorf("taint %q not found on cluster %s", FormatTaint(r), cluster.Name)
+		}
+		cluster.Spec.Taints = taints
+		changed = true
+	}
+
+	for _, a := range add {
+		a.TimeAdded = metav1.NewTime(now)
+		found := false
+		for i, t := range cluster.Spec.Taints {
+			if t.Key != a.Key || t.Effect != a.Effect {
+				continue
+			}
+			found = true
+			if t.Value == a.Value {
+				break
+			}
+			if !overwrite {
+				return false, fmt.Errorf("cluster %s already has taint %s, set --overwrite to update it", cluster.Name, FormatTaint(t))
+			}
+			cluster.Spec.Taints[i] = a
+			changed = true
+		}
+		if !found {
+			cluster.Spec.Taints = append(cluster.Spec.Taints, a)
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// HasTaint returns true if the cluster has the taint with the key and effect.
+func HasTaint(cluster *clusterv1.ManagedCluster, key string, effect clusterv1.TaintEffect) bool {
+	for _, t := range cluster.Spec.Taints {
+		if t.Key == key && t.Effect == effect {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateCluster gets the cluster, mutates it and updates it if it is changed, and retries on conflicts.
+// It returns the updated cluster and whether it is changed. The cluster is not updated if dryRun is true.
+func UpdateCluster(
+	ctx context.Context,
+	client clusterclientset.Interface,
+	name string,
+	dryRun bool,
+	mutate func(cluster *clusterv1.ManagedCluster) (bool, error)) (*clusterv1.ManagedCluster, bool, error) {
+	var cluster *clusterv1.ManagedCluster
+	changed := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		cluster, err = client.ClusterV1().ManagedClusters().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		changed, err = mutate(cluster)
+		if err != nil || !changed || dryRun {
+			return err
+		}
+		cluster, err = client.ClusterV1().ManagedClusters().Update(ctx, cluster, metav1.UpdateOptions{})
+		return err
+	})
+	return cluster, changed, err
+}
+
+// FormatTaint returns the taint in the format of {key}[={value}][:{effect}].
+func FormatTaint(taint clusterv1.Taint) string {
+	s := taint.Key
+	if len(taint.Value) > 0 {
+		s += "=" + taint.Value
+	}
+	if len(taint.Effect) > 0 {
+		s += ":" + string(taint.Effect)
+	}
+	return s
+}
+
+// Cordon adds the cordon taint to the cluster if cordon is true, otherwise removes it. It returns false
+// if the cluster is already cordoned or uncordoned.
+func Cordon(ctx context.Context, client clusterclientset.Interface, name string, cordon, dryRun bool) (bool, error) {
+	_, changed, err := UpdateCluster(ctx, client, name, dryRun, func(cluster *clusterv1.ManagedCluster) (bool, error) {
+		if HasTaint(cluster, CordonTaintKey, clusterv1.TaintEffectNoSelect) == cordon {
+			return false, nil
+		}
+		taint := []clusterv1.Taint{{Key: CordonTaintKey, Effect: clusterv1.TaintEffectNoSelect}}
+		if cordon {
+			return ApplyTaints(cluster, taint, nil, false, time.Now())
+		}
+		return ApplyTaints(cluster, nil, taint, false, time.Now())
+	})
+	return changed, err
+}
@@ -0,0 +1,115 @@
+// Copyright Contributors to the Open Cluster Management project
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func TestApplyTaints(t *testing.T) {
+	now := time.Now()
+	existing := clusterv1.Taint{Key: "gpu", Value: "false", Effect: clusterv1.TaintEffectNoSelect, TimeAdded: metav1.NewTime(now)}
+
+	cases := []struct {
+		name           string
+		specs          []string
+		overwrite      bool
+		expectParseErr bool
+		expectErr      bool
+		expectChanged  bool
+		expectTaints   []string
+	}{
+		{
+			name:           "invalid effect",
+			specs:          []string{"gpu=true:NoSchedule"},
+			expectParseErr: true,
+		},
+		{
+			name:           "missing effect",
+			specs:          []string{"gpu=true"},
+			expectParseErr: true,
+		},
+		{
+			name:          "add a taint",
+			specs:         []string{"maintenance:NoSelectIfNew"},
+			expectChanged: true,
+			expectTaints:  []string{"gpu=false:NoSelect", "maintenance:NoSelectIfNew"},
+		},
+		{
+			name:         "add an existing taint",
+			specs:        []string{"gpu=false:NoSelect"},
+			expectTaints: []string{"gpu=false:NoSelect"},
+		},
+		{
+			name:      "update a taint without overwrite",
+			specs:     []string{"gpu=true:NoSelect"},
+			expectErr: true,
+		},
+		{
+			name:          "update a taint with overwrite",
+			specs:         []string{"gpu=true:NoSelect"},
+			overwrite:     true,
+			expectChanged: true,
+			expectTaints:  []string{"gpu=true:NoSelect"},
+		},
+		{
+			name:          "remove a taint by key",
+			specs:         []string{"gpu-"},
+			expectChanged: true,
+		},
+		{
+			name:      "remove a taint not found",
+			specs:     []string{"gpu:PreferNoSelect-"},
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			add, remove, err := ParseTaints(c.specs)
+			if err != nil {
+				if !c.expectParseErr {
+					t.Errorf("should not have error, but got %v", err)
+				}
+				return
+			}
+			if c.expectParseErr {
+				t.Fatalf("should return parse err")
+			}
+
+			cluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+				Spec:       clusterv1.ManagedClusterSpec{Taints: []clusterv1.Taint{existing}},
+			}
+			changed, err := ApplyTaints(cluster, add, remove, c.overwrite, now)
+			if err != nil {
+				if !c.expectErr {
+					t.Errorf("should not have error, but got %v", err)
+				}
+				return
+			}
+			if c.expectErr {
+				t.Fatalf("should return err")
+			}
+			if changed != c.expectChanged {
+				t.Errorf("expected changed %t, got %t", c.expectChanged, changed)
+			}
+			var taints []string
+			for _, taint := range cluster.Spec.Taints {
+				taints = append(taints, FormatTaint(taint))
+			}
+			if len(taints) != len(c.expectTaints) {
+				t.Fatalf("expected taints %v, got %v", c.expectTaints, taints)
+			}
+			for i := range taints {
+				if taints[i] != c.expectTaints[i] {
+					t.Errorf("expected taints %v, got %v", c.expectTaints, taints)
+				}
+			}
+		})
+	}
+}
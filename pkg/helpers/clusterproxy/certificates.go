@@ -1,5 +1,5 @@
 // Copyright Contributors to the Open Cluster Management project
-package kubectl
+package clusterproxy
 
 import (
 	"context"
@@ -20,7 +20,8 @@ const (
 	inClusterSecretClient  = "proxy-client"
 )
 
-type proxyCertificates struct {
+// ProxyCertificates holds the certificates of the cluster-proxy server and client read from the hub.
+type ProxyCertificates struct {
 	ca         []byte
 	serverCert []byte
 	serverKey  []byte
@@ -28,13 +29,14 @@ type proxyCertificates struct {
 	clientKey  []byte
 }
 
-func getProxyCertificates(hubRestConfig *rest.Config, proxyConfig *proxyv1alpha1.ManagedProxyConfiguration) (*proxyCertificates, error) {
+// GetProxyCertificates reads the CA, server and client certificates of cluster-proxy from the hub.
+func GetProxyCertificates(hubRestConfig *rest.Config, proxyConfig *proxyv1alpha1.ManagedProxyConfiguration) (*ProxyCertificates, error) {
 	nativeClient, err := kubernetes.NewForConfig(hubRestConfig)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed building cilent")
 	}
 
-	pc := &proxyCertificates{}
+	pc := &ProxyCertificates{}
 
 	// ca
 	caSecret, err := nativeClient.CoreV1().Secrets(proxyConfig.Spec.ProxyServer.Namespace).
@@ -65,7 +67,8 @@ func getProxyCertificates(hubRestConfig *rest.Config, proxyConfig *proxyv1alpha1
 	return pc, nil
 }
 
-func buildTLSConfig(caData, certData, keyData []byte, serverName string, protos []string) (*tls.Config, error) {
+// BuildTLSConfig builds a tls config from pem-encoded data.
+func BuildTLSConfig(caData, certData, keyData []byte, serverName string, protos []string) (*tls.Config, error) {
 	certPool := x509.NewCertPool()
 	certPool.AppendCertsFromPEM(caData)
 	cert, err := tls.X509KeyPair(certData, keyData)
@@ -0,0 +1,173 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterproxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	addonv1alpha1client "open-cluster-management.io/api/client/addon/clientset/versioned"
+	proxyv1alpha1 "open-cluster-management.io/cluster-proxy/pkg/apis/proxy/v1alpha1"
+	"open-cluster-management.io/cluster-proxy/pkg/common"
+	clusterproxyclient "open-cluster-management.io/cluster-proxy/pkg/generated/clientset/versioned"
+	"open-cluster-management.io/cluster-proxy/pkg/util"
+	"open-cluster-management.io/clusteradm/pkg/config"
+	msaclientset "open-cluster-management.io/managed-serviceaccount/pkg/generated/clientset/versioned"
+)
+
+// GetProxyConfig returns the ManagedProxyConfiguration of cluster-proxy. A nil configuration is
+// returned after printing a hint if the cluster-proxy addon is not installed.
+func GetProxyConfig(hubRestConfig *rest.Config, streams genericiooptions.IOStreams) (*proxyv1alpha1.ManagedProxyConfiguration, error) {
+	addonClient, err := addonv1alpha1client.NewForConfig(hubRestConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed initializing addon api client")
+	}
+
+	_, err = addonClient.AddonV1alpha1().ClusterManagementAddOns().Get(
+		context.TODO(),
+		"cluster-proxy",
+		metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			if _, err := fmt.Fprintf(
+				streams.Out,
+				"Cluster-Proxy addon is not installed.\n"); err != nil {
+				return nil, err
+			}
+			if _, err := fmt.Fprintf(
+				streams.Out,
+				"Consider following the guide: https://open-cluster-management.io/getting-started/integration/cluster-proxy/\n"); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed checking cluster management addon for cluster-proxy")
+	}
+
+	proxyClient, err := clusterproxyclient.NewForConfig(hubRestConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed initializing proxy api client")
+	}
+
+	proxyConfig, err := proxyClient.ProxyV1alpha1().ManagedProxyConfigurations().
+		Get(context.TODO(), config.ManagedProxyConfigurationName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting managedproxyconfiguration for cluster-proxy")
+	}
+
+	return proxyConfig, nil
+}
+
+// GetManagedServiceAccountToken returns the token of the ManagedServiceAccount in the cluster namespace.
+func GetManagedServiceAccountToken(hubRestConfig *rest.Config, msaName string, namespace string) (string, error) {
+	msaClient, err := msaclientset.NewForConfig(hubRestConfig)
+	if err != nil {
+		return "", err
+	}
+
+	msa, err := msaClient.AuthenticationV1beta1().ManagedServiceAccounts(namespace).Get(context.TODO(), msaName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(hubRestConfig)
+	if err != nil {
+		return "", err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), msa.Status.TokenSecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := secret.Data["token"]
+	if !ok {
+		return "", errors.Errorf("token is not found in secret %s", secret.Name)
+	}
+
+	return string(token), nil
+}
+
+// listenAttempts is the number of free ports tried to listen on, as a free port may be taken by another
+// process before the local proxy listens on it.
+const listenAttempts = 5
+
+// ListenLocalProxy port-forwards the local port to the proxy-server pods on the hub, a free port is
+// allocated if the port is 0, and another one is tried if it is taken before listening on it. The
+// local port and a func to stop listening are returned.
+func ListenLocalProxy(ctx context.Context, hubRestConfig *rest.Config, proxyConfig *proxyv1alpha1.ManagedProxyConfiguration, port int32) (int32, func(), error) {
+	attempts := 1
+	if port == 0 {
+		attempts = listenAttempts
+	}
+
+	var errs []error
+	for i := 0; i < attempts; i++ {
+		listenPort := port
+		if listenPort == 0 {
+			var err error
+			if listenPort, err = FreeLocalPort(); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		readiness := &atomic.Value{}
+		readiness.Store(true)
+		localProxy := util.NewRoundRobinLocalProxy(
+			hubRestConfig,
+			readiness,
+			proxyConfig.Spec.ProxyServer.Namespace,
+			common.LabelKeyComponentName+"="+common.ComponentNameProxyServer,
+			listenPort,
+		)
+		closeFn, err := localProxy.Listen(ctx)
+		if err == nil {
+			return listenPort, closeFn, nil
+		}
+		errs = append(errs, errors.Wrapf(err, "failed listening local proxy on port %d", listenPort))
+	}
+	return 0, nil, utilerrors.NewAggregate(errs)
+}
+
+// FreeLocalPort returns a port on localhost which is free to listen on.
+func FreeLocalPort() (int32, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed allocating a free local port")
+	}
+	defer listener.Close()
+	return int32(listener.Addr().(*net.TCPAddr).Port), nil
+}
+
+// NewClusterRESTConfig returns a rest config of the managed cluster dialing through the konnectivity
+// tunnel of the proxy-server listening on the local port, authenticated with the token.
+func NewClusterRESTConfig(ctx context.Context, cluster string, proxyServerPort int32, pc *ProxyCertificates, token string) (*rest.Config, error) {
+	proxyClientTLSCfg, err := BuildTLSConfig(pc.ca, pc.clientCert, pc.clientKey, "localhost", nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed building TLS config from secret")
+	}
+	getTunnel := newTunnelFunc(ctx, proxyServerPort, proxyClientTLSCfg)
+
+	return &rest.Config{
+		Host:        fmt.Sprintf("https://%s", cluster),
+		BearerToken: token,
+		// The konnectivity tunnel is routing based on the name of the managed cluster, so the
+		// kube-apiserver of the managed cluster is not able to be verified by its hostname.
+		TLSClientConfig: rest.TLSClientConfig{Insecure: true},
+		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			tunnel, err := getTunnel()
+			if err != nil {
+				return nil, err
+			}
+			return tunnel.DialContext(ctx, network, addr)
+		},
+	}, nil
+}
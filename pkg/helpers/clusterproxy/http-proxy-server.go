@@ -1,5 +1,5 @@
 // Copyright Contributors to the Open Cluster Management project
-package kubectl
+package clusterproxy
 
 import (
 	"context"
@@ -21,75 +21,89 @@ import (
 	konnectivity "sigs.k8s.io/apiserver-network-proxy/konnectivity-client/pkg/client"
 )
 
-type httpProxyServer struct {
+// HTTPProxyServer forwards the requests it receives to the kube-apiserver of a managed cluster
+// through the konnectivity tunnel of cluster-proxy.
+type HTTPProxyServer struct {
 	getTunnel       func() (konnectivity.Tunnel, error)
 	serverTLSConfig *tls.Config
 	cluster         string
 }
 
-func newHTTPProxyServer(
+// NewHTTPProxyServer returns a HTTPProxyServer connecting to the proxy-server listening on the local port.
+func NewHTTPProxyServer(
 	ctx context.Context,
 	cluster string,
 	proxyServerPort int32,
-	pc *proxyCertificates,
-) (*httpProxyServer, error) {
+	pc *ProxyCertificates,
+) (*HTTPProxyServer, error) {
 	// build client tls config, using to access proxy-server
-	proxyClientTLSCfg, err := buildTLSConfig(pc.ca, pc.clientCert, pc.clientKey, "localhost", nil)
+	proxyClientTLSCfg, err := BuildTLSConfig(pc.ca, pc.clientCert, pc.clientKey, "localhost", nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed building TLS config from secret")
 	}
 
 	// build server tls config and use proxyServer's tls to start this http-proxyserver as well
-	proxyServerTLSCfg, err := buildTLSConfig(pc.ca, pc.serverCert, pc.serverKey, "localhost", nil)
+	proxyServerTLSCfg, err := BuildTLSConfig(pc.ca, pc.serverCert, pc.serverKey, "localhost", nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed building TLS config from secret")
 	}
 
-	return &httpProxyServer{
-		getTunnel: func() (konnectivity.Tunnel, error) {
-			// instantiate a gprc proxy dialer
-			tunnel, err := konnectivity.CreateSingleUseGrpcTunnel(
-				ctx,
-				net.JoinHostPort("localhost", strconv.Itoa(int(proxyServerPort))),
-				grpc.WithTransportCredentials(grpccredentials.NewTLS(proxyClientTLSCfg)),
-				grpc.WithKeepaliveParams(keepalive.ClientParameters{
-					Time: time.Second * 5,
-				}),
-			)
-			if err != nil {
-				return nil, err
-			}
-			return tunnel, nil
-		},
+	return &HTTPProxyServer{
+		getTunnel:       newTunnelFunc(ctx, proxyServerPort, proxyClientTLSCfg),
 		serverTLSConfig: proxyServerTLSCfg,
 		cluster:         cluster,
 	}, nil
 }
 
-func (s *httpProxyServer) Listen(ctx context.Context, port int32) error {
+// newTunnelFunc returns a func creating a single use konnectivity tunnel to the proxy-server listening on the local port.
+func newTunnelFunc(ctx context.Context, proxyServerPort int32, tlsCfg *tls.Config) func() (konnectivity.Tunnel, error) {
+	return func() (konnectivity.Tunnel, error) {
+		// instantiate a gprc proxy dialer
+		tunnel, err := konnectivity.CreateSingleUseGrpcTunnel(
+			ctx,
+			net.JoinHostPort("localhost", strconv.Itoa(int(proxyServerPort))),
+			grpc.WithTransportCredentials(grpccredentials.NewTLS(tlsCfg)),
+			grpc.WithKeepaliveParams(keepalive.ClientParameters{
+				Time: time.Second * 5,
+			}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return tunnel, nil
+	}
+}
+
+// Listen serves on the given local port until the context is done, a free port is allocated if the port
+// is 0. The port listened on is returned.
+func (s *HTTPProxyServer) Listen(ctx context.Context, port int32) (int32, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(int(port))))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed listening on local port %d", port)
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", s.handle)
 
 	srv := &http.Server{
-		Addr:      fmt.Sprintf(":%d", port),
 		Handler:   mux,
 		TLSConfig: s.serverTLSConfig,
 	}
 	go func() {
-		if err := srv.ListenAndServeTLS("", ""); err != nil {
-			runtime.HandleError(errors.Wrapf(err, "failed to listen http proxy server"))
+		if err := srv.ServeTLS(listener, "", ""); err != nil && err != http.ErrServerClosed {
+			runtime.HandleError(errors.Wrapf(err, "failed to serve http proxy server"))
 		}
 	}()
 	go func() {
 		<-ctx.Done()
-		if err := srv.Shutdown(ctx); err != nil {
+		if err := srv.Shutdown(context.Background()); err != nil {
 			runtime.HandleError(errors.Wrapf(err, "failed to shutdown http proxy server"))
 		}
 	}()
-	return nil
+	return int32(listener.Addr().(*net.TCPAddr).Port), nil
 }
 
-func (s *httpProxyServer) handle(wr http.ResponseWriter, req *http.Request) {
+func (s *HTTPProxyServer) handle(wr http.ResponseWriter, req *http.Request) {
 	if klog.V(4).Enabled() {
 		dump, err := httputil.DumpRequest(req, true)
 		if err != nil {
@@ -0,0 +1,207 @@
+// Copyright Contributors to the Open Cluster Management project
+package clusterproxy
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	addonclient "open-cluster-management.io/api/client/addon/clientset/versioned"
+	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+	msav1beta1 "open-cluster-management.io/managed-serviceaccount/apis/authentication/v1beta1"
+	msaclientset "open-cluster-management.io/managed-serviceaccount/pkg/generated/clientset/versioned"
+)
+
+const (
+	managedServiceAccountAddonName = "managed-serviceaccount"
+
+	// defaultAddonInstallNamespace is the namespace of the service accounts of the ManagedServiceAccounts
+	// if the install namespace is not reported by the addon
+	defaultAddonInstallNamespace = "open-cluster-management-agent-addon"
+
+	temporaryServiceAccountPrefix = "clusteradm-"
+)
+
+// GetHubUserToken returns the bearer token of the current user of the hub. It is forwarded by the
+// cluster-proxy, which impersonates the hub user on the managed cluster.
+func GetHubUserToken(hubRestConfig *rest.Config) (string, error) {
+	if len(hubRestConfig.BearerToken) > 0 {
+		return hubRestConfig.BearerToken, nil
+	}
+	if len(hubRestConfig.BearerTokenFile) > 0 {
+		token, err := os.ReadFile(hubRestConfig.BearerTokenFile)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed reading token file %s", hubRestConfig.BearerTokenFile)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+	return "", errors.New("the hub user is not authenticated with a bearer token, which is required to be impersonated")
+}
+
+// CreateTemporaryServiceAccount creates a ManagedServiceAccount deleted after the ttl on the managed cluster,
+// and a ManifestWork binding its service account to the cluster role. The ManifestWork is owned by the
+// ManagedServiceAccount, so both are garbage collected after the ttl. The token and a func deleting them
+// are returned once the token is reported and the role binding is applied.
+func CreateTemporaryServiceAccount(
+	ctx context.Context,
+	hubRestConfig *rest.Config,
+	cluster, clusterRole string,
+	ttl, timeout time.Duration) (string, func(), error) {
+	msaClient, err := msaclientset.NewForConfig(hubRestConfig)
+	if err != nil {
+		return "", nil, err
+	}
+	workClient, err := workclientset.NewForConfig(hubRestConfig)
+	if err != nil {
+		return "", nil, err
+	}
+	addonClient, err := addonclient.NewForConfig(hubRestConfig)
+	if err != nil {
+		return "", nil, err
+	}
+
+	namespace, err := managedServiceAccountNamespace(ctx, addonClient, cluster)
+	if err != nil {
+		return "", nil, err
+	}
+
+	name := temporaryServiceAccountPrefix + utilrand.String(8)
+	msa, err := msaClient.AuthenticationV1beta1().ManagedServiceAccounts(cluster).Create(ctx, &msav1beta1.ManagedServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster,
+		},
+		Spec: msav1beta1.ManagedServiceAccountSpec{
+			Rotation: msav1beta1.ManagedServiceAccountRotation{
+				Enabled:  true,
+				Validity: metav1.Duration{Duration: ttl},
+			},
+			TTLSecondsAfterCreation: ptr.To[int32](int32(ttl.Seconds())),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed creating temporary managed service account on cluster %s", cluster)
+	}
+
+	cleanup := func() {
+		// the context of the command may be done already
+		ctx := context.Background()
+		if err := workClient.WorkV1().ManifestWorks(cluster).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			klog.Warningf("failed deleting manifestwork %s/%s: %v", cluster, name, err)
+		}
+		if err := msaClient.AuthenticationV1beta1().ManagedServiceAccounts(cluster).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			klog.Warningf("failed deleting managed service account %s/%s: %v", cluster, name, err)
+		}
+	}
+
+	work := newRoleBindingWork(msa, namespace, clusterRole)
+	if _, err := workClient.WorkV1().ManifestWorks(cluster).Create(ctx, work, metav1.CreateOptions{}); err != nil {
+		cleanup()
+		return "", nil, errors.Wrapf(err, "failed creating manifestwork binding cluster role %s on cluster %s", clusterRole, cluster)
+	}
+
+	klog.V(1).InfoS("waiting for the temporary managed service account", "cluster", cluster, "name", name)
+	err = wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		work, err := workClient.WorkV1().ManifestWorks(cluster).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		msa, err := msaClient.AuthenticationV1beta1().ManagedServiceAccounts(cluster).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return meta.IsStatusConditionTrue(work.Status.Conditions, workapiv1.WorkApplied) && msa.Status.TokenSecretRef != nil, nil
+	})
+	if err != nil {
+		cleanup()
+		return "", nil, errors.Wrapf(err, "failed waiting for temporary managed service account %s on cluster %s", name, cluster)
+	}
+
+	token, err := GetManagedServiceAccountToken(hubRestConfig, name, cluster)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return token, cleanup, nil
+}
+
+// managedServiceAccountNamespace returns the namespace of the service accounts created by the
+// managed-serviceaccount addon on the managed cluster.
+func managedServiceAccountNamespace(ctx context.Context, addonClient addonclient.Interface, cluster string) (string, error) {
+	addon, err := addonClient.AddonV1alpha1().ManagedClusterAddOns(cluster).Get(ctx, managedServiceAccountAddonName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", errors.Errorf("the managed-serviceaccount addon is not enabled on cluster %s", cluster)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(addon.Status.Namespace) > 0 {
+		return addon.Status.Namespace, nil
+	}
+	if len(addon.Spec.InstallNamespace) > 0 {
+		return addon.Spec.InstallNamespace, nil
+	}
+	return defaultAddonInstallNamespace, nil
+}
+
+// newRoleBindingWork returns a ManifestWork owned by the ManagedServiceAccount, which binds the service
+// account in the namespace to the cluster role on the managed cluster.
+func newRoleBindingWork(msa *msav1beta1.ManagedServiceAccount, namespace, clusterRole string) *workapiv1.ManifestWork {
+	binding := &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: msa.Name,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      msa.Name,
+				Namespace: namespace,
+			},
+		},
+	}
+
+	return &workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      msa.Name,
+			Namespace: msa.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: msav1beta1.GroupVersion.String(),
+					Kind:       "ManagedServiceAccount",
+					Name:       msa.Name,
+					UID:        msa.UID,
+				},
+			},
+		},
+		Spec: workapiv1.ManifestWorkSpec{
+			Workload: workapiv1.ManifestsTemplate{
+				Manifests: []workapiv1.Manifest{
+					{RawExtension: runtime.RawExtension{Object: binding}},
+				},
+			},
+		},
+	}
+}
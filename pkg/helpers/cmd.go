@@ -23,3 +23,18 @@ func DryRunMessage(dryRun bool) {
 		fmt.Printf("%s is running in dry-run mode\n", GetExampleHeader())
 	}
 }
+
+// ExitError is an error with the exit code of the process, e.g. the exit code of a command run in-process, which
+// is passed through instead of the default exit code 1.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,91 @@
+// Copyright Contributors to the Open Cluster Management project
+package helm
+
+import (
+	"fmt"
+	"io"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	"sigs.k8s.io/yaml"
+)
+
+// RenderChart renders the chart in a local directory or archive like `helm template`, without connecting
+// to a cluster. The values are merged from the values files in order, so the later ones take precedence.
+// It returns the rendered manifests including the CRDs of the chart, the hooks are not included.
+func RenderChart(chartPath, releaseName, namespace string, valuesFiles ...[]byte) (string, error) {
+	vals, err := MergeValues(valuesFiles...)
+	if err != nil {
+		return "", err
+	}
+	return RenderChartValues(chartPath, releaseName, namespace, vals)
+}
+
+// MergeValues merges the values files in order, so the later ones take precedence.
+func MergeValues(valuesFiles ...[]byte) (map[string]interface{}, error) {
+	vals := map[string]interface{}{}
+	for _, valuesFile := range valuesFiles {
+		current := map[string]interface{}{}
+		if err := yaml.Unmarshal(valuesFile, &current); err != nil {
+			return nil, fmt.Errorf("failed to parse values: %v", err)
+		}
+		vals = chartutil.MergeTables(current, vals)
+	}
+	return vals, nil
+}
+
+// ChartValues returns the default values of the chart merged with the values.
+func ChartValues(chartPath string, vals map[string]interface{}) (map[string]interface{}, error) {
+	chartRequested, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s: %v", chartPath, err)
+	}
+	return chartutil.CoalesceValues(chartRequested, vals)
+}
+
+// RenderChartValues renders the chart in a local directory or archive with the values, see RenderChart.
+func RenderChartValues(chartPath, releaseName, namespace string, vals map[string]interface{}) (string, error) {
+	chartRequested, err := loader.Load(chartPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load chart %s: %v", chartPath, err)
+	}
+	if ok, err := isChartInstallable(chartRequested); !ok {
+		return "", err
+	}
+
+	actionConfig := &action.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(string, ...interface{}) {},
+	}
+	client := action.NewInstall(actionConfig)
+	client.DryRun = true
+	client.ClientOnly = true
+	client.Replace = true
+	client.IncludeCRDs = true
+	client.ReleaseName = releaseName
+	client.Namespace = namespace
+
+	release, err := client.Run(chartRequested, vals)
+	if err != nil {
+		return "", fmt.Errorf("failed to render chart %s: %v", chartPath, err)
+	}
+	return release.Manifest, nil
+}
+
+// LocateChart returns the path of the chart in a local directory or archive, or downloads the chart in the
+// format of {repo}/{chart} from a repository added by `helm repo add` to the cache of helm.
+func LocateChart(name, version string) (string, error) {
+	pathOptions := action.ChartPathOptions{Version: version}
+	path, err := pathOptions.LocateChart(name, cli.New())
+	if err != nil {
+		return "", fmt.Errorf("failed to locate chart %s: %v", name, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,115 @@
+// Copyright Contributors to the Open Cluster Management project
+package helm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// variablePattern matches the variables of an AddOnTemplate, e.g. {{CLUSTER_NAME}}
+	variablePattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+	// placeholderPattern matches the placeholders of the variables, also after the case is changed by the chart
+	placeholderPattern = regexp.MustCompile(`(?i)__ocm_var_([a-z0-9_]+?)__`)
+)
+
+// Variables are the variables of an AddOnTemplate in the values of a chart. They are replaced by placeholders
+// before the chart is rendered, as the values are not valid YAML or templates of the chart otherwise, and
+// the placeholders are restored to the variables in the rendered manifests.
+type Variables map[string]string
+
+// Placeholder returns the placeholder of the variable, which is a plain YAML scalar.
+func (v Variables) Placeholder(name string) string {
+	placeholder := fmt.Sprintf("__OCM_VAR_%s__", name)
+	v[strings.ToLower(placeholder)] = name
+	return placeholder
+}
+
+// Escape replaces the variables in the values file with the placeholders.
+func (v Variables) Escape(data []byte) []byte {
+	return variablePattern.ReplaceAllFunc(data, func(match []byte) []byte {
+		return []byte(v.Placeholder(string(variablePattern.FindSubmatch(match)[1])))
+	})
+}
+
+// Restore replaces the placeholders in the keys and the string values of the object with the variables.
+func (v Variables) Restore(obj interface{}) interface{} {
+	switch t := obj.(type) {
+	case map[string]interface{}:
+		restored := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			restored[v.restoreString(key)] = v.Restore(value)
+		}
+		return restored
+	case []interface{}:
+		for i := range t {
+			t[i] = v.Restore(t[i])
+		}
+		return t
+	case string:
+		return v.restoreString(t)
+	}
+	return obj
+}
+
+func (v Variables) restoreString(s string) string {
+	return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name, ok := v[strings.ToLower(match)]
+		if !ok {
+			return match
+		}
+		return fmt.Sprintf("{{%s}}", name)
+	})
+}
+
+// Value returns the value of the path like image.tag in the values.
+func Value(vals map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = vals
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// StringValue returns the string value of the path like image.tag in the values, a null value is empty. The
+// other types are not allowed, as the value is replaced by a variable rendered as a string in the manifests.
+func StringValue(vals map[string]interface{}, path string) (string, error) {
+	value, ok := Value(vals, path)
+	if !ok {
+		return "", fmt.Errorf("the value %s is not found", path)
+	}
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("the value %s is %v of type %T, only string values are allowed", path, value, value)
+}
+
+// SetValue sets the value of the path like image.tag in the values, the missing maps are created.
+func SetValue(vals map[string]interface{}, path string, value interface{}) error {
+	keys := strings.Split(path, ".")
+	current := vals
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key]
+		if !ok || next == nil {
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+		m, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("the value %s of %s is not a map", key, path)
+		}
+		current = m
+	}
+	current[keys[len(keys)-1]] = value
+	return nil
+}
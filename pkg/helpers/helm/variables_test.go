@@ -0,0 +1,88 @@
+// Copyright Contributors to the Open Cluster Management project
+package helm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVariables(t *testing.T) {
+	variables := Variables{}
+	vals, err := MergeValues(variables.Escape([]byte("clusterName: {{CLUSTER_NAME}}\nhub:\n  kubeconfig: \"{{ HUB_KUBECONFIG }}\"\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetValue(vals, "image.tag", variables.Placeholder("IMAGE_TAG")); err != nil {
+		t.Fatal(err)
+	}
+
+	// the values as rendered by the templates of a chart, with the placeholders lowered in a name
+	rendered := map[string]interface{}{
+		"name": "agent-" + "__ocm_var_cluster_name__",
+		"args": []interface{}{
+			"--cluster=" + vals["clusterName"].(string),
+			"--kubeconfig=" + vals["hub"].(map[string]interface{})["kubeconfig"].(string),
+		},
+		"image":    "agent:" + vals["image"].(map[string]interface{})["tag"].(string),
+		"replicas": int64(1),
+	}
+	expected := map[string]interface{}{
+		"name":     "agent-{{CLUSTER_NAME}}",
+		"args":     []interface{}{"--cluster={{CLUSTER_NAME}}", "--kubeconfig={{HUB_KUBECONFIG}}"},
+		"image":    "agent:{{IMAGE_TAG}}",
+		"replicas": int64(1),
+	}
+	if actual := variables.Restore(rendered); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestValue(t *testing.T) {
+	vals := map[string]interface{}{"image": map[string]interface{}{"tag": "v1"}}
+	if value, ok := Value(vals, "image.tag"); !ok || value != "v1" {
+		t.Errorf("expected v1, got %v", value)
+	}
+	if _, ok := Value(vals, "image.tag.name"); ok {
+		t.Errorf("expected the value not to be found")
+	}
+	if err := SetValue(vals, "image.tag.name", "v2"); err == nil {
+		t.Errorf("expected an error to set a value in a string")
+	}
+}
+
+func TestStringValue(t *testing.T) {
+	vals, err := MergeValues([]byte("image:\n  tag: v1\n  digest: null\nreplicas: 3\ndebug: true\nargs: [a]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		path      string
+		expected  string
+		expectErr bool
+	}{
+		{path: "image.tag", expected: "v1"},
+		{path: "image.digest", expected: ""},
+		{path: "image.name", expectErr: true},
+		{path: "replicas", expectErr: true},
+		{path: "debug", expectErr: true},
+		{path: "args", expectErr: true},
+		{path: "image", expectErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			value, err := StringValue(vals, c.path)
+			if c.expectErr {
+				if err == nil {
+					t.Errorf("expected an error, got %q", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright Contributors to the Open Cluster Management project
+package placement
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+// Check is one step of the evaluation of a placement on a cluster.
+type Check struct {
+	Name    string
+	Passed  bool
+	Message string
+}
+
+// Explanation explains why a cluster is or is not selected by a placement.
+type Explanation struct {
+	Cluster string
+	// Decided is true if the cluster is in the current decisions of the placement on the hub
+	Decided bool
+	// Selected is true if the cluster is selected by evaluating the placement against the input
+	Selected bool
+	Checks   []Check
+	Warnings []string
+}
+
+// Explain evaluates the placement against the input step by step for the cluster, with the same filters
+// and scores as Simulate. An error is returned if the cluster is not found.
+func Explain(input *Input, clusterName string) (*Explanation, error) {
+	var cluster *clusterv1.ManagedCluster
+	for i := range input.Clusters {
+		if input.Clusters[i].Name == clusterName {
+			cluster = &input.Clusters[i]
+			break
+		}
+	}
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster %s is not found", clusterName)
+	}
+
+	result := Simulate(input)
+	decided := decidedClusters(input)
+	explanation := &Explanation{
+		Cluster:  clusterName,
+		Decided:  decided.Has(clusterName),
+		Selected: result.Cluster(clusterName).Selected,
+		Warnings: result.Warnings,
+	}
+	placement := input.Placement
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		explanation.Checks = append(explanation.Checks, Check{Name: "Deleting", Message: "the cluster is being deleted"})
+	}
+
+	eligibleClusters, _ := clustersOfBoundClusterSets(input)
+	clusterSetCheck := Check{Name: "ClusterSets", Passed: eligibleClusters.Has(clusterName)}
+	if clusterSetCheck.Passed {
+		clusterSetCheck.Message = "the cluster is in a clusterset bound to the namespace of the placement"
+	} else {
+		clusterSetCheck.Message = "the cluster is not in the clustersets bound to the namespace of the placement"
+	}
+	if len(placement.Spec.ClusterSets) > 0 {
+		clusterSetCheck.Message += fmt.Sprintf(" and selected by the placement (%s)", strings.Join(placement.Spec.ClusterSets, ","))
+	}
+	explanation.Checks = append(explanation.Checks, clusterSetCheck)
+
+	if len(placement.Spec.Predicates) == 0 {
+		explanation.Checks = append(explanation.Checks, Check{Name: "Predicates", Passed: true, Message: "no predicates"})
+	}
+	for i, predicate := range placement.Spec.Predicates {
+		check := Check{Name: fmt.Sprintf("Predicates[%d]", i)}
+		matched, err := MatchPredicates([]clusterv1beta1.ClusterPredicate{predicate}, cluster)
+		switch {
+		case err != nil:
+			check.Message = err.Error()
+		case matched:
+			check.Passed = true
+			check.Message = "the cluster matches the label and claim selectors"
+		default:
+			check.Message = "the cluster does not match the label or claim selectors"
+		}
+		explanation.Checks = append(explanation.Checks, check)
+	}
+
+	taintCheck := Check{Name: "Taints", Passed: true, Message: "no untolerated taints"}
+	if taint, ok := UntoleratedTaint(placement.Spec.Tolerations, cluster, explanation.Decided, input.Now); ok {
+		taintCheck.Passed = false
+		taintCheck.Message = fmt.Sprintf("the taint %s is not tolerated", formatTaint(taint))
+	}
+	explanation.Checks = append(explanation.Checks, taintCheck)
+
+	explanation.Checks = append(explanation.Checks, scoreCheck(result, clusterName))
+	return explanation, nil
+}
+
+// scoreCheck returns the rank of the cluster among the feasible clusters by scores.
+func scoreCheck(result *Result, clusterName string) Check {
+	check := Check{Name: "Scores"}
+	rank, feasible := 0, sets.New[string]()
+	for _, c := range result.Clusters {
+		if !c.Feasible {
+			continue
+		}
+		feasible.Insert(c.Name)
+		if c.Name == clusterName {
+			rank = feasible.Len()
+		}
+	}
+
+	clusterResult := result.Cluster(clusterName)
+	if !clusterResult.Feasible {
+		check.Message = "the cluster is filtered out before scoring"
+		return check
+	}
+	check.Passed = clusterResult.Selected
+	check.Message = fmt.Sprintf("the cluster scores %d and ranks %d of %d feasible clusters", clusterResult.Score, rank, feasible.Len())
+	if !clusterResult.Selected {
+		check.Message += ", " + clusterResult.Reason
+	}
+	return check
+}
@@ -0,0 +1,119 @@
+// Copyright Contributors to the Open Cluster Management project
+package placement
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+)
+
+func TestExplain(t *testing.T) {
+	now := time.Now()
+	clusters := []clusterv1.ManagedCluster{
+		newCluster("cluster1", map[string]string{"env": "prod"}, "2"),
+		newCluster("cluster2", map[string]string{"env": "dev"}, "2"),
+		newCluster("cluster3", map[string]string{"env": "prod"}, "2",
+			clusterv1.Taint{Key: "maintenance", Effect: clusterv1.TaintEffectNoSelect, TimeAdded: metav1.NewTime(now)}),
+	}
+	input := &Input{
+		Placement: &clusterv1beta1.Placement{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: clusterv1beta1.PlacementSpec{
+				Predicates: []clusterv1beta1.ClusterPredicate{{
+					RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+						LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+					},
+				}},
+			},
+		},
+		Clusters:    clusters,
+		ClusterSets: []clusterv1beta2.ManagedClusterSet{{ObjectMeta: metav1.ObjectMeta{Name: "set1"}}},
+		Bindings: []clusterv1beta2.ManagedClusterSetBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "set1", Namespace: "default"},
+			Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: "set1"},
+			Status: clusterv1beta2.ManagedClusterSetBindingStatus{Conditions: []metav1.Condition{{
+				Type: clusterv1beta2.ClusterSetBindingBoundType, Status: metav1.ConditionTrue,
+			}}},
+		}},
+		Decisions: []clusterv1beta1.PlacementDecision{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-decision-1",
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1beta1.PlacementLabel: "test"},
+			},
+			Status: clusterv1beta1.PlacementDecisionStatus{
+				Decisions: []clusterv1beta1.ClusterDecision{{ClusterName: "cluster1"}},
+			},
+		}},
+		Now: now,
+	}
+
+	cases := []struct {
+		name           string
+		cluster        string
+		expectErr      bool
+		expectSelected bool
+		expectDecided  bool
+		expectFailed   []string
+	}{
+		{
+			name:      "cluster not found",
+			cluster:   "cluster4",
+			expectErr: true,
+		},
+		{
+			name:           "selected cluster",
+			cluster:        "cluster1",
+			expectSelected: true,
+			expectDecided:  true,
+		},
+		{
+			name:         "cluster not matching the predicate",
+			cluster:      "cluster2",
+			expectFailed: []string{"Predicates[0]", "Scores"},
+		},
+		{
+			name:         "tainted cluster",
+			cluster:      "cluster3",
+			expectFailed: []string{"Taints", "Scores"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			explanation, err := Explain(input, c.cluster)
+			if err != nil {
+				if !c.expectErr {
+					t.Errorf("should not have error, but got %v", err)
+				}
+				return
+			}
+			if c.expectErr {
+				t.Fatalf("should return err")
+			}
+			if explanation.Selected != c.expectSelected || explanation.Decided != c.expectDecided {
+				t.Errorf("expected selected %t and decided %t, got %t and %t",
+					c.expectSelected, c.expectDecided, explanation.Selected, explanation.Decided)
+			}
+			var failed []string
+			for _, check := range explanation.Checks {
+				if !check.Passed {
+					failed = append(failed, check.Name)
+				}
+			}
+			if len(failed) != len(c.expectFailed) {
+				t.Fatalf("expected failed checks %v, got %v", c.expectFailed, failed)
+			}
+			for i := range failed {
+				if failed[i] != c.expectFailed[i] {
+					t.Errorf("expected failed checks %v, got %v", c.expectFailed, failed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright Contributors to the Open Cluster Management project
+package placement
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clusterclientset "open-cluster-management.io/api/client/cluster/clientset/versioned"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+	clustersdkv1beta2 "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta2"
+)
+
+const (
+	PrioritizerBalance                = "Balance"
+	PrioritizerSteady                 = "Steady"
+	PrioritizerResourceAllocatableCPU = "ResourceAllocatableCPU"
+	PrioritizerResourceAllocatableMem = "ResourceAllocatableMemory"
+
+	maxScore = int64(100)
+	minScore = int64(-100)
+)
+
+// Input is the state of the hub a placement is simulated against.
+type Input struct {
+	Placement   *clusterv1beta1.Placement
+	Clusters    []clusterv1.ManagedCluster
+	ClusterSets []clusterv1beta2.ManagedClusterSet
+	// Bindings are the ManagedClusterSetBindings in the namespace of the placement
+	Bindings []clusterv1beta2.ManagedClusterSetBinding
+	// Decisions are the PlacementDecisions of all the placements on the hub
+	Decisions []clusterv1beta1.PlacementDecision
+	Now       time.Time
+}
+
+// Prioritizer is a prioritizer used to score the clusters with its weight.
+type Prioritizer struct {
+	Name   string
+	Weight int32
+	// Simulated is false if the scores of the prioritizer are not able to be computed offline
+	Simulated bool
+}
+
+// ClusterResult is the result of simulating the placement on a cluster.
+type ClusterResult struct {
+	Name string
+	// Feasible is true if the cluster passes the clustersets, predicates and taints filters
+	Feasible bool
+	// Selected is true if the cluster would be selected by the placement
+	Selected bool
+	// Reason explains why the cluster is not selected
+	Reason string
+	// Scores are the normalized scores per prioritizer in the range of [-100, 100]
+	Scores map[string]int64
+	// Score is the sum of the scores multiplied by the weights of the prioritizers
+	Score int64
+}
+
+// Result is the result of simulating a placement.
+type Result struct {
+	Prioritizers []Prioritizer
+	// Clusters are the selected clusters sorted by scores, followed by the clusters not selected
+	Clusters []ClusterResult
+	Warnings []string
+}
+
+// SelectedClusters returns the names of the selected clusters.
+func (r *Result) SelectedClusters() []string {
+	var names []string
+	for _, c := range r.Clusters {
+		if c.Selected {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// Cluster returns the result of the cluster, or nil if the cluster is not found.
+func (r *Result) Cluster(name string) *ClusterResult {
+	for i := range r.Clusters {
+		if r.Clusters[i].Name == name {
+			return &r.Clusters[i]
+		}
+	}
+	return nil
+}
+
+// LoadInput reads the clusters, clustersets, bindings and decisions on the hub to simulate the placement.
+func LoadInput(ctx context.Context, clusterClient clusterclientset.Interface, placement *clusterv1beta1.Placement) (*Input, error) {
+	clusters, err := clusterClient.ClusterV1().ManagedClusters().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	clusterSets, err := clusterClient.ClusterV1beta2().ManagedClusterSets().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := clusterClient.ClusterV1beta2().ManagedClusterSetBindings(placement.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	decisions, err := clusterClient.ClusterV1beta1().PlacementDecisions(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Input{
+		Placement:   placement,
+		Clusters:    clusters.Items,
+		ClusterSets: clusterSets.Items,
+		Bindings:    bindings.Items,
+		Decisions:   decisions.Items,
+		Now:         time.Now(),
+	}, nil
+}
+
+// Simulate evaluates the placement against the input the same way as the placement controller: the
+// clusters are filtered by the bound clustersets, the predicates and the taints, then scored by the
+// prioritizers, and the clusters with the highest scores are selected up to the NumberOfClusters.
+func Simulate(input *Input) *Result {
+	result := &Result{
+		Prioritizers: prioritizersOf(input.Placement),
+	}
+
+	eligibleClusters, err := clustersOfBoundClusterSets(input)
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+	for _, predicate := range input.Placement.Spec.Predicates {
+		if len(predicate.RequiredClusterSelector.CelSelector.CelExpressions) > 0 {
+			result.Warnings = append(result.Warnings, "CEL expressions are not simulated and regarded as matched")
+			break
+		}
+	}
+	decided := decidedClusters(input)
+
+	var feasible []*clusterv1.ManagedCluster
+	clusters := make(map[string]*ClusterResult, len(input.Clusters))
+	for i := range input.Clusters {
+		cluster := &input.Clusters[i]
+		clusterResult := &ClusterResult{Name: cluster.Name, Scores: map[string]int64{}}
+		clusters[cluster.Name] = clusterResult
+
+		reason, err := FilterCluster(input.Placement, cluster, eligibleClusters, decided.Has(cluster.Name), input.Now)
+		if err != nil {
+			clusterResult.Reason = err.Error()
+			continue
+		}
+		if len(reason) > 0 {
+			clusterResult.Reason = reason
+			continue
+		}
+		clusterResult.Feasible = true
+		feasible = append(feasible, cluster)
+	}
+
+	for _, prioritizer := range result.Prioritizers {
+		if !prioritizer.Simulated {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("prioritizer %s is not simulated and scores 0", prioritizer.Name))
+			continue
+		}
+		for name, score := range scoreClusters(prioritizer.Name, input, feasible, decided) {
+			clusters[name].Scores[prioritizer.Name] = score
+			clusters[name].Score += score * int64(prioritizer.Weight)
+		}
+	}
+
+	var feasibleResults, filteredResults []ClusterResult
+	for _, c := range clusters {
+		if c.Feasible {
+			feasibleResults = append(feasibleResults, *c)
+		} else {
+			filteredResults = append(filteredResults, *c)
+		}
+	}
+	sort.Slice(feasibleResults, func(i, j int) bool {
+		if feasibleResults[i].Score != feasibleResults[j].Score {
+			return feasibleResults[i].Score > feasibleResults[j].Score
+		}
+		return feasibleResults[i].Name < feasibleResults[j].Name
+	})
+	sort.Slice(filteredResults, func(i, j int) bool {
+		return filteredResults[i].Name < filteredResults[j].Name
+	})
+
+	for i := range feasibleResults {
+		numberOfClusters := input.Placement.Spec.NumberOfClusters
+		if numberOfClusters != nil && int32(i) >= *numberOfClusters {
+			feasibleResults[i].Reason = fmt.Sprintf("the score is not in the top %d", *numberOfClusters)
+			continue
+		}
+		feasibleResults[i].Selected = true
+	}
+
+	result.Clusters = append(feasibleResults, filteredResults...)
+	return result
+}
+
+// FilterCluster returns the reason why the cluster is filtered out by the placement, or an empty string
+// if the cluster passes the filters. decided is true if the cluster is in the decisions of the placement
+// already, which tolerates the NoSelectIfNew taints.
+func FilterCluster(
+	placement *clusterv1beta1.Placement,
+	cluster *clusterv1.ManagedCluster,
+	eligibleClusters sets.Set[string],
+	decided bool,
+	now time.Time) (string, error) {
+	if !cluster.DeletionTimestamp.IsZero() {
+		return "the cluster is being deleted", nil
+	}
+	if !eligibleClusters.Has(cluster.Name) {
+		return "the cluster is not in the clustersets bound to the namespace of the placement", nil
+	}
+
+	matched, err := MatchPredicates(placement.Spec.Predicates, cluster)
+	if err != nil {
+		return "", err
+	}
+	if !matched {
+		return "the cluster does not match the predicates", nil
+	}
+
+	if taint, ok := UntoleratedTaint(placement.Spec.Tolerations, cluster, decided, now); ok {
+		return fmt.Sprintf("the taint %s is not tolerated", formatTaint(taint)), nil
+	}
+	return "", nil
+}
+
+// MatchPredicates returns true if the cluster matches any of the predicates, a predicate matches if both
+// its label selector and claim selector match. CEL expressions are not evaluated.
+func MatchPredicates(predicates []clusterv1beta1.ClusterPredicate, cluster *clusterv1.ManagedCluster) (bool, error) {
+	if len(predicates) == 0 {
+		return true, nil
+	}
+
+	claims := labels.Set{}
+	for _, claim := range cluster.Status.ClusterClaims {
+		claims[claim.Name] = claim.Value
+	}
+	for _, predicate := range predicates {
+		labelSelector, err := metav1.LabelSelectorAsSelector(&predicate.RequiredClusterSelector.LabelSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid label selector: %v", err)
+		}
+		claimSelector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+			MatchExpressions: predicate.RequiredClusterSelector.ClaimSelector.MatchExpressions,
+		})
+		if err != nil {
+			return false, fmt.Errorf("invalid claim selector: %v", err)
+		}
+		if labelSelector.Matches(labels.Set(cluster.Labels)) && claimSelector.Matches(claims) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// UntoleratedTaint returns the first NoSelect or NoSelectIfNew taint of the cluster which is not tolerated.
+func UntoleratedTaint(tolerations []clusterv1beta1.Toleration, cluster *clusterv1.ManagedCluster, decided bool, now time.Time) (clusterv1.Taint, bool) {
+	for _, taint := range cluster.Spec.Taints {
+		switch taint.Effect {
+		case clusterv1.TaintEffectNoSelect:
+		case clusterv1.TaintEffectNoSelectIfNew:
+			if decided {
+				continue
+			}
+		default:
+			continue
+		}
+		if !tolerated(tolerations, taint, now) {
+			return taint, true
+		}
+	}
+	return clusterv1.Taint{}, false
+}
+
+func tolerated(tolerations []clusterv1beta1.Toleration, taint clusterv1.Taint, now time.Time) bool {
+	for _, toleration := range tolerations {
+		if len(toleration.Effect) > 0 && toleration.Effect != taint.Effect {
+			continue
+		}
+		switch toleration.Operator {
+		case clusterv1beta1.TolerationOpExists:
+			// an empty key with the Exists operator matches all the taints
+			if len(toleration.Key) > 0 && toleration.Key != taint.Key {
+				continue
+			}
+		case "", clusterv1beta1.TolerationOpEqual:
+			if toleration.Key != taint.Key || toleration.Value != taint.Value {
+				continue
+			}
+		default:
+			continue
+		}
+		if toleration.TolerationSeconds != nil &&
+			now.After(taint.TimeAdded.Add(time.Duration(*toleration.TolerationSeconds)*time.Second)) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func formatTaint(taint clusterv1.Taint) string {
+	if len(taint.Value) == 0 {
+		return fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+// clustersOfBoundClusterSets returns the clusters in the clustersets which are bound to the namespace of
+// the placement and, if the clustersets of the placement are specified, in the clustersets of the placement.
+func clustersOfBoundClusterSets(input *Input) (sets.Set[string], error) {
+	bound := sets.New[string]()
+	for _, binding := range input.Bindings {
+		if meta.IsStatusConditionTrue(binding.Status.Conditions, clusterv1beta2.ClusterSetBindingBoundType) {
+			bound.Insert(binding.Spec.ClusterSet)
+		}
+	}
+	if len(input.Placement.Spec.ClusterSets) > 0 {
+		bound = bound.Intersection(sets.New[string](input.Placement.Spec.ClusterSets...))
+	}
+
+	var errs []string
+	clusters := sets.New[string]()
+	for i := range input.ClusterSets {
+		clusterSet := &input.ClusterSets[i]
+		if !bound.Has(clusterSet.Name) {
+			continue
+		}
+		selector, err := clustersdkv1beta2.BuildClusterSelector(clusterSet)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("clusterset %s: %v", clusterSet.Name, err))
+			continue
+		}
+		for _, cluster := range input.Clusters {
+			if selector.Matches(labels.Set(cluster.Labels)) {
+				clusters.Insert(cluster.Name)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return clusters, fmt.Errorf("failed to select the clusters of %s", strings.Join(errs, "; "))
+	}
+	return clusters, nil
+}
+
+// decidedClusters returns the clusters in the decisions of the placement.
+func decidedClusters(input *Input) sets.Set[string] {
+	decided := sets.New[string]()
+	for _, decision := range input.Decisions {
+		if decision.Namespace == input.Placement.Namespace &&
+			decision.Labels[clusterv1beta1.PlacementLabel] == input.Placement.Name {
+			for _, d := range decision.Status.Decisions {
+				decided.Insert(d.ClusterName)
+			}
+		}
+	}
+	return decided
+}
+
+// prioritizersOf returns the prioritizers of the placement. In the Additive mode, the Balance and Steady
+// prioritizers are enabled with weight 1 unless they are configured.
+func prioritizersOf(placement *clusterv1beta1.Placement) []Prioritizer {
+	var prioritizers []Prioritizer
+	configured := sets.New[string]()
+	for _, config := range placement.Spec.PrioritizerPolicy.Configurations {
+		if config.ScoreCoordinate == nil {
+			continue
+		}
+		prioritizer := Prioritizer{Weight: config.Weight}
+		switch config.ScoreCoordinate.Type {
+		case clusterv1beta1.ScoreCoordinateTypeAddOn:
+			if config.ScoreCoordinate.AddOn != nil {
+				prioritizer.Name = fmt.Sprintf("AddOn/%s/%s",
+					config.ScoreCoordinate.AddOn.ResourceName, config.ScoreCoordinate.AddOn.ScoreName)
+			}
+		default:
+			prioritizer.Name = config.ScoreCoordinate.BuiltIn
+			prioritizer.Simulated = isSimulatedBuiltIn(prioritizer.Name)
+		}
+		configured.Insert(prioritizer.Name)
+		prioritizers = append(prioritizers, prioritizer)
+	}
+
+	if placement.Spec.PrioritizerPolicy.Mode != clusterv1beta1.PrioritizerPolicyModeExact {
+		for _, name := range []string{PrioritizerBalance, PrioritizerSteady} {
+			if !configured.Has(name) {
+				prioritizers = append(prioritizers, Prioritizer{Name: name, Weight: 1, Simulated: true})
+			}
+		}
+	}
+	return prioritizers
+}
+
+func isSimulatedBuiltIn(name string) bool {
+	switch name {
+	case PrioritizerBalance, PrioritizerSteady, PrioritizerResourceAllocatableCPU, PrioritizerResourceAllocatableMem:
+		return true
+	}
+	return false
+}
+
+// scoreClusters returns the normalized scores of the feasible clusters by the builtin prioritizer.
+func scoreClusters(prioritizer string, input *Input, feasible []*clusterv1.ManagedCluster, decided sets.Set[string]) map[string]int64 {
+	scores := map[string]int64{}
+	switch prioritizer {
+	case PrioritizerSteady:
+		// the clusters selected already score the max
+		for _, cluster := range feasible {
+			scores[cluster.Name] = 0
+			if decided.Has(cluster.Name) {
+				scores[cluster.Name] = maxScore
+			}
+		}
+	case PrioritizerBalance:
+		// the clusters selected by fewer other placements score higher
+		counts := map[string]int64{}
+		for _, decision := range input.Decisions {
+			if decision.Namespace == input.Placement.Namespace &&
+				decision.Labels[clusterv1beta1.PlacementLabel] == input.Placement.Name {
+				continue
+			}
+			for _, d := range decision.Status.Decisions {
+				counts[d.ClusterName]++
+			}
+		}
+		var maxCount int64
+		for _, cluster := range feasible {
+			if counts[cluster.Name] > maxCount {
+				maxCount = counts[cluster.Name]
+			}
+		}
+		for _, cluster := range feasible {
+			scores[cluster.Name] = maxScore
+			if maxCount > 0 {
+				scores[cluster.Name] = 2*maxScore*(maxCount-counts[cluster.Name])/maxCount + minScore
+			}
+		}
+	case PrioritizerResourceAllocatableCPU, PrioritizerResourceAllocatableMem:
+		// the clusters with more allocatable resource score higher
+		resourceName := clusterv1.ResourceCPU
+		if prioritizer == PrioritizerResourceAllocatableMem {
+			resourceName = clusterv1.ResourceMemory
+		}
+		allocatable := map[string]int64{}
+		var minValue, maxValue int64
+		for i, cluster := range feasible {
+			quantity := cluster.Status.Allocatable[resourceName]
+			value := quantity.MilliValue()
+			allocatable[cluster.Name] = value
+			if i == 0 || value < minValue {
+				minValue = value
+			}
+			if i == 0 || value > maxValue {
+				maxValue = value
+			}
+		}
+		for _, cluster := range feasible {
+			scores[cluster.Name] = maxScore
+			if maxValue > minValue {
+				scores[cluster.Name] = 2*maxScore*(allocatable[cluster.Name]-minValue)/(maxValue-minValue) + minScore
+			}
+		}
+	}
+	return scores
+}
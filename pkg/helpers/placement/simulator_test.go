@@ -0,0 +1,125 @@
+// Copyright Contributors to the Open Cluster Management project
+package placement
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+)
+
+func newCluster(name string, labels map[string]string, cpu string, taints ...clusterv1.Taint) clusterv1.ManagedCluster {
+	labels[clusterv1beta2.ClusterSetLabel] = "set1"
+	return clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       clusterv1.ManagedClusterSpec{Taints: taints},
+		Status: clusterv1.ManagedClusterStatus{
+			Allocatable: clusterv1.ResourceList{clusterv1.ResourceCPU: resource.MustParse(cpu)},
+		},
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	now := time.Now()
+	clusters := []clusterv1.ManagedCluster{
+		newCluster("cluster1", map[string]string{"env": "prod"}, "2"),
+		newCluster("cluster2", map[string]string{"env": "prod"}, "8"),
+		newCluster("cluster3", map[string]string{"env": "prod"}, "4"),
+		newCluster("cluster4", map[string]string{"env": "dev"}, "16"),
+		newCluster("cluster5", map[string]string{"env": "prod"}, "32",
+			clusterv1.Taint{Key: "maintenance", Effect: clusterv1.TaintEffectNoSelect, TimeAdded: metav1.NewTime(now)}),
+	}
+	clusterSets := []clusterv1beta2.ManagedClusterSet{{ObjectMeta: metav1.ObjectMeta{Name: "set1"}}}
+	bindings := []clusterv1beta2.ManagedClusterSetBinding{{
+		ObjectMeta: metav1.ObjectMeta{Name: "set1", Namespace: "default"},
+		Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: "set1"},
+		Status: clusterv1beta2.ManagedClusterSetBindingStatus{Conditions: []metav1.Condition{{
+			Type: clusterv1beta2.ClusterSetBindingBoundType, Status: metav1.ConditionTrue,
+		}}},
+	}}
+	prodSelector := []clusterv1beta1.ClusterPredicate{{
+		RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+			LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		},
+	}}
+	cpuPrioritizer := clusterv1beta1.PrioritizerPolicy{
+		Mode: clusterv1beta1.PrioritizerPolicyModeExact,
+		Configurations: []clusterv1beta1.PrioritizerConfig{{
+			ScoreCoordinate: &clusterv1beta1.ScoreCoordinate{
+				Type:    clusterv1beta1.ScoreCoordinateTypeBuiltIn,
+				BuiltIn: PrioritizerResourceAllocatableCPU,
+			},
+			Weight: 1,
+		}},
+	}
+
+	cases := []struct {
+		name             string
+		spec             clusterv1beta1.PlacementSpec
+		bindings         []clusterv1beta2.ManagedClusterSetBinding
+		expectedSelected []string
+		expectedScores   map[string]int64
+	}{
+		{
+			name:             "no bound clustersets",
+			expectedSelected: nil,
+		},
+		{
+			name:             "select by labels and filter out the tainted cluster",
+			spec:             clusterv1beta1.PlacementSpec{Predicates: prodSelector},
+			bindings:         bindings,
+			expectedSelected: []string{"cluster1", "cluster2", "cluster3"},
+		},
+		{
+			name: "tolerate the taint",
+			spec: clusterv1beta1.PlacementSpec{
+				Predicates:  prodSelector,
+				Tolerations: []clusterv1beta1.Toleration{{Key: "maintenance", Operator: clusterv1beta1.TolerationOpExists}},
+			},
+			bindings:         bindings,
+			expectedSelected: []string{"cluster1", "cluster2", "cluster3", "cluster5"},
+		},
+		{
+			name: "select the clusters with most allocatable cpu",
+			spec: clusterv1beta1.PlacementSpec{
+				Predicates:        prodSelector,
+				NumberOfClusters:  ptr.To[int32](2),
+				PrioritizerPolicy: cpuPrioritizer,
+			},
+			bindings:         bindings,
+			expectedSelected: []string{"cluster2", "cluster3"},
+			expectedScores:   map[string]int64{"cluster1": -100, "cluster2": 100, "cluster3": -34},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result := Simulate(&Input{
+				Placement: &clusterv1beta1.Placement{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+					Spec:       c.spec,
+				},
+				Clusters:    clusters,
+				ClusterSets: clusterSets,
+				Bindings:    c.bindings,
+				Now:         now,
+			})
+
+			if selected := result.SelectedClusters(); !reflect.DeepEqual(selected, c.expectedSelected) {
+				t.Errorf("expected selected clusters %v, got %v", c.expectedSelected, selected)
+			}
+			for cluster, score := range c.expectedScores {
+				if actual := result.Cluster(cluster).Score; actual != score {
+					t.Errorf("expected score %d of %s, got %d", score, cluster, actual)
+				}
+			}
+		})
+	}
+}
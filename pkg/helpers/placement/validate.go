@@ -0,0 +1,167 @@
+// Copyright Contributors to the Open Cluster Management project
+package placement
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+const (
+	// the range of the weight of a prioritizer accepted by the placement API
+	minPrioritizerWeight = -10
+	maxPrioritizerWeight = 10
+)
+
+var (
+	builtInPrioritizers = sets.New[string](PrioritizerBalance, PrioritizerSteady,
+		PrioritizerResourceAllocatableCPU, PrioritizerResourceAllocatableMem)
+	taintEffects = sets.New[clusterv1.TaintEffect]("", clusterv1.TaintEffectNoSelect,
+		clusterv1.TaintEffectPreferNoSelect, clusterv1.TaintEffectNoSelectIfNew)
+)
+
+// ValidateSpec validates the placement spec with the rules of the placement API, so an invalid spec is
+// reported before it is sent to the hub.
+func ValidateSpec(spec *clusterv1beta1.PlacementSpec) error {
+	var errs []error
+
+	if spec.NumberOfClusters != nil && *spec.NumberOfClusters < 0 {
+		errs = append(errs, fmt.Errorf("numberOfClusters must not be negative"))
+	}
+
+	for i, predicate := range spec.Predicates {
+		selector := predicate.RequiredClusterSelector
+		if _, err := metav1.LabelSelectorAsSelector(&selector.LabelSelector); err != nil {
+			errs = append(errs, fmt.Errorf("predicates[%d].labelSelector is invalid: %v", i, err))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+			MatchExpressions: selector.ClaimSelector.MatchExpressions,
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("predicates[%d].claimSelector is invalid: %v", i, err))
+		}
+		for j, expression := range selector.CelSelector.CelExpressions {
+			if len(strings.TrimSpace(expression)) == 0 {
+				errs = append(errs, fmt.Errorf("predicates[%d].celSelector.celExpressions[%d] must not be empty", i, j))
+			}
+		}
+	}
+
+	mode := spec.PrioritizerPolicy.Mode
+	if mode != "" && mode != clusterv1beta1.PrioritizerPolicyModeAdditive && mode != clusterv1beta1.PrioritizerPolicyModeExact {
+		errs = append(errs, fmt.Errorf("prioritizerPolicy.mode %q is invalid, should be Additive or Exact", mode))
+	}
+	for i, config := range spec.PrioritizerPolicy.Configurations {
+		if config.Weight < minPrioritizerWeight || config.Weight > maxPrioritizerWeight {
+			errs = append(errs, fmt.Errorf("prioritizerPolicy.configurations[%d].weight must be in [%d, %d]",
+				i, minPrioritizerWeight, maxPrioritizerWeight))
+		}
+		if config.ScoreCoordinate == nil {
+			errs = append(errs, fmt.Errorf("prioritizerPolicy.configurations[%d].scoreCoordinate must be set", i))
+			continue
+		}
+		switch config.ScoreCoordinate.Type {
+		case clusterv1beta1.ScoreCoordinateTypeBuiltIn:
+			if !builtInPrioritizers.Has(config.ScoreCoordinate.BuiltIn) {
+				errs = append(errs, fmt.Errorf("prioritizerPolicy.configurations[%d] has unknown builtIn prioritizer %q, should be one of %s",
+					i, config.ScoreCoordinate.BuiltIn, strings.Join(sets.List(builtInPrioritizers), ",")))
+			}
+		case clusterv1beta1.ScoreCoordinateTypeAddOn:
+			if config.ScoreCoordinate.AddOn == nil ||
+				len(config.ScoreCoordinate.AddOn.ResourceName) == 0 || len(config.ScoreCoordinate.AddOn.ScoreName) == 0 {
+				errs = append(errs, fmt.Errorf("prioritizerPolicy.configurations[%d].scoreCoordinate.addOn must have resourceName and scoreName", i))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("prioritizerPolicy.configurations[%d].scoreCoordinate.type %q is invalid", i, config.ScoreCoordinate.Type))
+		}
+	}
+
+	for i, term := range spec.SpreadPolicy.SpreadConstraints {
+		if len(term.TopologyKey) == 0 {
+			errs = append(errs, fmt.Errorf("spreadPolicy.spreadConstraints[%d].topologyKey must be set", i))
+		}
+		if term.TopologyKeyType != clusterv1beta1.TopologyKeyTypeLabel && term.TopologyKeyType != clusterv1beta1.TopologyKeyTypeClaim {
+			errs = append(errs, fmt.Errorf("spreadPolicy.spreadConstraints[%d].topologyKeyType %q is invalid, should be Label or Claim",
+				i, term.TopologyKeyType))
+		}
+		if term.MaxSkew < 1 {
+			errs = append(errs, fmt.Errorf("spreadPolicy.spreadConstraints[%d].maxSkew must be at least 1", i))
+		}
+		if term.WhenUnsatisfiable != "" && term.WhenUnsatisfiable != clusterv1beta1.DoNotSchedule &&
+			term.WhenUnsatisfiable != clusterv1beta1.ScheduleAnyway {
+			errs = append(errs, fmt.Errorf("spreadPolicy.spreadConstraints[%d].whenUnsatisfiable %q is invalid, should be DoNotSchedule or ScheduleAnyway",
+				i, term.WhenUnsatisfiable))
+		}
+	}
+
+	for i, toleration := range spec.Tolerations {
+		switch toleration.Operator {
+		case "", clusterv1beta1.TolerationOpEqual:
+			if len(toleration.Key) == 0 {
+				errs = append(errs, fmt.Errorf("tolerations[%d].key must be set with the Equal operator", i))
+			}
+		case clusterv1beta1.TolerationOpExists:
+			if len(toleration.Value) > 0 {
+				errs = append(errs, fmt.Errorf("tolerations[%d].value must be empty with the Exists operator", i))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("tolerations[%d].operator %q is invalid, should be Equal or Exists", i, toleration.Operator))
+		}
+		if !taintEffects.Has(toleration.Effect) {
+			errs = append(errs, fmt.Errorf("tolerations[%d].effect %q is invalid", i, toleration.Effect))
+		}
+		if toleration.TolerationSeconds != nil && *toleration.TolerationSeconds < 0 {
+			errs = append(errs, fmt.Errorf("tolerations[%d].tolerationSeconds must not be negative", i))
+		}
+	}
+
+	groupStrategy := spec.DecisionStrategy.GroupStrategy
+	// the zero value is not set, and defaulted to 100% by the api
+	if groupStrategy.ClustersPerDecisionGroup != (intstr.IntOrString{}) {
+		if err := ValidateClustersPerDecisionGroup(groupStrategy.ClustersPerDecisionGroup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	groupNames := sets.New[string]()
+	for i, group := range groupStrategy.DecisionGroups {
+		if len(group.GroupName) == 0 {
+			errs = append(errs, fmt.Errorf("decisionStrategy.groupStrategy.decisionGroups[%d].groupName must be set", i))
+		} else if groupNames.Has(group.GroupName) {
+			errs = append(errs, fmt.Errorf("decisionStrategy.groupStrategy.decisionGroups[%d].groupName %q is duplicated", i, group.GroupName))
+		}
+		groupNames.Insert(group.GroupName)
+		if _, err := metav1.LabelSelectorAsSelector(&group.ClusterSelector.LabelSelector); err != nil {
+			errs = append(errs, fmt.Errorf("decisionStrategy.groupStrategy.decisionGroups[%d].groupClusterSelector is invalid: %v", i, err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// ValidateClustersPerDecisionGroup accepts a positive number or a percentage in (0%, 100%].
+func ValidateClustersPerDecisionGroup(value intstr.IntOrString) error {
+	if value.Type == intstr.Int {
+		if value.IntVal <= 0 {
+			return fmt.Errorf("decisionStrategy.groupStrategy.clustersPerDecisionGroup must be positive")
+		}
+		return nil
+	}
+	if len(value.StrVal) == 0 {
+		return nil
+	}
+
+	str := value.StrVal
+	percentage := strings.HasSuffix(str, "%")
+	n, err := strconv.Atoi(strings.TrimSuffix(str, "%"))
+	if err != nil || n < 1 || (percentage && n > 100) {
+		return fmt.Errorf("decisionStrategy.groupStrategy.clustersPerDecisionGroup %q is invalid, should be a positive number or a percentage in (0%%, 100%%]", str)
+	}
+	return nil
+}
@@ -19,6 +19,9 @@ type PrinterOption struct {
 	table   printers.ResourcePrinter
 	yaml    printers.YAMLPrinter
 
+	// defaultFormat is the value of the output flag when it is not set, tree if empty.
+	defaultFormat string
+
 	treeConverter  func(runtime.Object, *TreePrinter) *TreePrinter
 	tableConverter func(runtime.Object) *metav1.Table
 }
@@ -29,8 +32,18 @@ func NewPrinterOption(o printers.PrintOptions) *PrinterOption {
 	}
 }
 
+// WithDefaultFormat overrides the default output format, it must be called before AddFlag.
+func (p *PrinterOption) WithDefaultFormat(format string) *PrinterOption {
+	p.defaultFormat = format
+	return p
+}
+
 func (p *PrinterOption) AddFlag(fs *pflag.FlagSet) {
-	fs.StringVarP(&p.Format, "output", "o", "tree", "output format can be tree, table or yaml")
+	format := "tree"
+	if len(p.defaultFormat) > 0 {
+		format = p.defaultFormat
+	}
+	fs.StringVarP(&p.Format, "output", "o", format, "output format can be tree, table or yaml")
 }
 
 func (p *PrinterOption) Competele() {
@@ -0,0 +1,183 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+// ReapplyAnnotation is the annotation bumped on a work to trigger the work agent to apply it again.
+const ReapplyAnnotation = "clusteradm.open-cluster-management.io/reapplied-at"
+
+// FieldDrift is a field of a resource whose live value on the managed cluster differs from the manifest.
+type FieldDrift struct {
+	// Field is the JSON pointer of the field, it is empty if the resource is not found on the cluster
+	Field    string
+	Expected string
+	Actual   string
+}
+
+// Drifts compares the fields set in the manifest with the live object, the live object is nil if it is not
+// found. The fields not in the manifest are not applied by the work agent, so they are not compared, and
+// neither are the status and the metadata other than the labels and annotations. The fields under the
+// ignored JSON pointers are not compared either.
+func Drifts(manifest, live *unstructured.Unstructured, ignored []string) []FieldDrift {
+	if live == nil {
+		return []FieldDrift{{Expected: "present", Actual: "not found"}}
+	}
+
+	desired := map[string]interface{}{}
+	for key, value := range manifest.Object {
+		switch key {
+		case "apiVersion", "kind", "status":
+			continue
+		case "metadata":
+			metadata, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			filtered := map[string]interface{}{}
+			for _, field := range []string{"labels", "annotations"} {
+				if v, ok := metadata[field]; ok {
+					filtered[field] = v
+				}
+			}
+			value = filtered
+		}
+		desired[key] = value
+	}
+
+	var drifts []FieldDrift
+	compareFields("", desired, live.Object, ignored, &drifts)
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Field < drifts[j].Field })
+	return drifts
+}
+
+func compareFields(path string, desired, actual interface{}, ignored []string, drifts *[]FieldDrift) {
+	for _, pointer := range ignored {
+		if path == pointer || strings.HasPrefix(path, strings.TrimSuffix(pointer, "/")+"/") {
+			return
+		}
+	}
+
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for key, value := range d {
+			compareFields(path+"/"+escapePointer(key), value, a[key], ignored, drifts)
+		}
+		return
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok || len(a) != len(d) {
+			break
+		}
+		for i := range d {
+			compareFields(path+"/"+strconv.Itoa(i), d[i], a[i], ignored, drifts)
+		}
+		return
+	default:
+		if equalValues(desired, actual) {
+			return
+		}
+	}
+
+	*drifts = append(*drifts, FieldDrift{Field: path, Expected: formatValue(desired), Actual: formatValue(actual)})
+}
+
+// equalValues compares the scalar values, the numbers are compared by value and the quantities are
+// compared after they are canonicalized by the api server, e.g. 1000m and 1.
+func equalValues(desired, actual interface{}) bool {
+	if desired == nil || actual == nil {
+		return desired == nil && actual == nil
+	}
+	if fmt.Sprintf("%v", desired) == fmt.Sprintf("%v", actual) {
+		return true
+	}
+	ds, dok := quantityString(desired)
+	as, aok := quantityString(actual)
+	if !dok || !aok {
+		return false
+	}
+	dq, err := resource.ParseQuantity(ds)
+	if err != nil {
+		return false
+	}
+	aq, err := resource.ParseQuantity(as)
+	if err != nil {
+		return false
+	}
+	return dq.Cmp(aq) == 0
+}
+
+func quantityString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+func formatValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "<none>"
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return string(data)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// IgnoredFields returns the JSON pointers of the fields ignored by the server side apply of the manifest
+// config. The JSON paths are converted to pointers if they are plain field paths like .spec.replicas,
+// the others are not supported.
+func IgnoredFields(config *workapiv1.ManifestConfigOption) []string {
+	if config == nil || config.UpdateStrategy == nil || config.UpdateStrategy.ServerSideApply == nil {
+		return nil
+	}
+	var pointers []string
+	for _, field := range config.UpdateStrategy.ServerSideApply.IgnoreFields {
+		pointers = append(pointers, field.JSONPointers...)
+		for _, path := range field.JSONPaths {
+			path = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(path, "{"), "$"), "}")
+			if !strings.HasPrefix(path, ".") || strings.ContainsAny(path, "[]*?@") {
+				continue
+			}
+			pointers = append(pointers, strings.ReplaceAll(path, ".", "/"))
+		}
+	}
+	return pointers
+}
+
+// EnforcedByAgent returns false if the work agent does not update the resource after it is created, so its
+// drift is expected.
+func EnforcedByAgent(config *workapiv1.ManifestConfigOption) bool {
+	if config == nil || config.UpdateStrategy == nil {
+		return true
+	}
+	return config.UpdateStrategy.Type != workapiv1.UpdateStrategyTypeCreateOnly &&
+		config.UpdateStrategy.Type != workapiv1.UpdateStrategyTypeReadOnly
+}
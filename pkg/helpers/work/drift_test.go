@@ -0,0 +1,53 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+func TestDrifts(t *testing.T) {
+	manifest := &unstructured.Unstructured{}
+	if err := manifest.UnmarshalJSON([]byte(`{"apiVersion":"apps/v1","kind":"Deployment",
+		"metadata":{"name":"web","namespace":"default","labels":{"app":"web"}},
+		"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"web","image":"nginx:1.25",
+		"resources":{"limits":{"cpu":"1000m"}}}]}}}}`)); err != nil {
+		t.Fatal(err)
+	}
+	live := &unstructured.Unstructured{}
+	if err := live.UnmarshalJSON([]byte(`{"apiVersion":"apps/v1","kind":"Deployment",
+		"metadata":{"name":"web","namespace":"default","uid":"1","labels":{"app":"web","team":"a"}},
+		"spec":{"replicas":5,"template":{"spec":{"containers":[{"name":"web","image":"nginx:1.26",
+		"imagePullPolicy":"Always","resources":{"limits":{"cpu":"1"}}}]}}},
+		"status":{"replicas":5}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []FieldDrift{
+		{Field: "/spec/replicas", Expected: "2", Actual: "5"},
+		{Field: "/spec/template/spec/containers/0/image", Expected: "nginx:1.25", Actual: "nginx:1.26"},
+	}
+	if drifts := Drifts(manifest, live, nil); !reflect.DeepEqual(drifts, expected) {
+		t.Errorf("expected drifts %v, got %v", expected, drifts)
+	}
+
+	ignored := IgnoredFields(&workapiv1.ManifestConfigOption{
+		UpdateStrategy: &workapiv1.UpdateStrategy{
+			Type: workapiv1.UpdateStrategyTypeServerSideApply,
+			ServerSideApply: &workapiv1.ServerSideApplyConfig{
+				IgnoreFields: []workapiv1.IgnoreField{{JSONPaths: []string{".spec.replicas"}}},
+			},
+		},
+	})
+	if drifts := Drifts(manifest, live, ignored); !reflect.DeepEqual(drifts, expected[1:]) {
+		t.Errorf("expected drifts %v, got %v", expected[1:], drifts)
+	}
+
+	if drifts := Drifts(manifest, nil, nil); len(drifts) != 1 || drifts[0].Actual != "not found" {
+		t.Errorf("expected the resource not found, got %v", drifts)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// serverPopulatedAnnotations are the annotations set by the api server, controllers or kubectl, which are
+// not part of the intent of the resource.
+var serverPopulatedAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.kubernetes.io/storage-provisioner",
+	"volume.beta.kubernetes.io/storage-provisioner",
+}
+
+// serverPopulatedFields are the fields of the kinds allocated by the cluster, which are not valid on
+// another cluster.
+var serverPopulatedFields = map[string][][]string{
+	"Service": {
+		{"spec", "clusterIP"},
+		{"spec", "clusterIPs"},
+		{"spec", "healthCheckNodePort"},
+	},
+	"PersistentVolumeClaim": {
+		{"spec", "volumeName"},
+	},
+}
+
+// jobGeneratedLabels are the labels of the pod template and the selector generated for a job, which are rejected
+// by the api server when a job is created with them without a manual selector.
+var jobGeneratedLabels = []string{
+	"controller-uid",
+	"batch.kubernetes.io/controller-uid",
+	"job-name",
+	"batch.kubernetes.io/job-name",
+}
+
+// headlessClusterIP is the cluster ip of the headless services, which is set by the users and kept.
+const headlessClusterIP = "None"
+
+// ExportObject returns a copy of the live object to be applied on other clusters, without the status and
+// the fields populated by the server, e.g. the uid, resource version and managed fields.
+func ExportObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	exported := obj.DeepCopy()
+	unstructured.RemoveNestedField(exported.Object, "status")
+	for _, field := range []string{
+		"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp",
+		"deletionGracePeriodSeconds", "managedFields", "selfLink", "ownerReferences", "finalizers",
+	} {
+		unstructured.RemoveNestedField(exported.Object, "metadata", field)
+	}
+
+	annotations := exported.GetAnnotations()
+	for _, annotation := range serverPopulatedAnnotations {
+		delete(annotations, annotation)
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	exported.SetAnnotations(annotations)
+
+	for _, field := range serverPopulatedFields[exported.GetKind()] {
+		if isHeadless(exported, field) {
+			continue
+		}
+		unstructured.RemoveNestedField(exported.Object, field...)
+	}
+
+	switch exported.GetKind() {
+	case "Service":
+		removeNodePorts(exported)
+	case "Job":
+		removeJobSelector(exported)
+	}
+	return exported
+}
+
+// removeNodePorts removes the node ports of the service ports allocated by the cluster.
+func removeNodePorts(obj *unstructured.Unstructured) {
+	ports, found, _ := unstructured.NestedSlice(obj.Object, "spec", "ports")
+	if !found {
+		return
+	}
+	for _, port := range ports {
+		if p, ok := port.(map[string]interface{}); ok {
+			delete(p, "nodePort")
+		}
+	}
+	_ = unstructured.SetNestedSlice(obj.Object, ports, "spec", "ports")
+}
+
+// removeJobSelector removes the selector of a job generated by the api server, and its labels in the pod
+// template, the selector set by the users with manualSelector is kept.
+func removeJobSelector(obj *unstructured.Unstructured) {
+	if manual, _, _ := unstructured.NestedBool(obj.Object, "spec", "manualSelector"); manual {
+		return
+	}
+	unstructured.RemoveNestedField(obj.Object, "spec", "selector")
+
+	labels, found, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+	if !found {
+		return
+	}
+	for _, label := range jobGeneratedLabels {
+		delete(labels, label)
+	}
+	if len(labels) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "spec", "template", "metadata", "labels")
+		return
+	}
+	_ = unstructured.SetNestedStringMap(obj.Object, labels, "spec", "template", "metadata", "labels")
+}
+
+// isHeadless returns true if the field is the cluster ip or ips of a headless service.
+func isHeadless(obj *unstructured.Unstructured, field []string) bool {
+	value, _, _ := unstructured.NestedFieldNoCopy(obj.Object, field...)
+	switch v := value.(type) {
+	case string:
+		return v == headlessClusterIP
+	case []interface{}:
+		return len(v) == 1 && v[0] == headlessClusterIP
+	}
+	return false
+}
+
+// Exportable returns false if the object is managed by a controller, or created in every namespace by
+// the cluster, e.g. the default service account and the kube-root-ca.crt configmap.
+func Exportable(obj *unstructured.Unstructured) bool {
+	for _, owner := range obj.GetOwnerReferences() {
+		if owner.Controller != nil && *owner.Controller {
+			return false
+		}
+	}
+	switch {
+	case obj.GetKind() == "ServiceAccount" && obj.GetName() == "default":
+		return false
+	case obj.GetKind() == "ConfigMap" && obj.GetName() == "kube-root-ca.crt":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestExportObject(t *testing.T) {
+	cases := []struct {
+		name     string
+		live     string
+		expected map[string]interface{}
+	}{
+		{
+			name: "service",
+			live: `{"apiVersion":"v1","kind":"Service",
+				"metadata":{"name":"web","namespace":"app","uid":"1","resourceVersion":"2","creationTimestamp":"2024-01-01T00:00:00Z",
+				"labels":{"app":"web"},"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{}"},
+				"managedFields":[{"manager":"kubectl"}]},
+				"spec":{"clusterIP":"10.0.0.1","clusterIPs":["10.0.0.1"],"ports":[{"port":80}],"selector":{"app":"web"}},
+				"status":{"loadBalancer":{}}}`,
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name":      "web",
+					"namespace": "app",
+					"labels":    map[string]interface{}{"app": "web"},
+				},
+				"spec": map[string]interface{}{
+					"ports":    []interface{}{map[string]interface{}{"port": int64(80)}},
+					"selector": map[string]interface{}{"app": "web"},
+				},
+			},
+		},
+		{
+			name: "headless service",
+			live: `{"apiVersion":"v1","kind":"Service",
+				"metadata":{"name":"db","namespace":"app","uid":"1","resourceVersion":"2"},
+				"spec":{"clusterIP":"None","clusterIPs":["None"],"ports":[{"port":5432}],"selector":{"app":"db"}}}`,
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name":      "db",
+					"namespace": "app",
+				},
+				"spec": map[string]interface{}{
+					"clusterIP":  "None",
+					"clusterIPs": []interface{}{"None"},
+					"ports":      []interface{}{map[string]interface{}{"port": int64(5432)}},
+					"selector":   map[string]interface{}{"app": "db"},
+				},
+			},
+		},
+		{
+			name: "node port service",
+			live: `{"apiVersion":"v1","kind":"Service",
+				"metadata":{"name":"web","namespace":"app","uid":"1"},
+				"spec":{"type":"LoadBalancer","clusterIP":"10.0.0.1","externalTrafficPolicy":"Local","healthCheckNodePort":31000,
+				"ports":[{"port":80,"nodePort":30080},{"port":443,"nodePort":30443}],"selector":{"app":"web"}}}`,
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name":      "web",
+					"namespace": "app",
+				},
+				"spec": map[string]interface{}{
+					"type":                  "LoadBalancer",
+					"externalTrafficPolicy": "Local",
+					"ports": []interface{}{
+						map[string]interface{}{"port": int64(80)},
+						map[string]interface{}{"port": int64(443)},
+					},
+					"selector": map[string]interface{}{"app": "web"},
+				},
+			},
+		},
+		{
+			name: "job",
+			live: `{"apiVersion":"batch/v1","kind":"Job",
+				"metadata":{"name":"migrate","namespace":"app","uid":"1","labels":{"app":"migrate"}},
+				"spec":{"selector":{"matchLabels":{"batch.kubernetes.io/controller-uid":"1"}},
+				"template":{"metadata":{"labels":{"app":"migrate","batch.kubernetes.io/controller-uid":"1",
+				"batch.kubernetes.io/job-name":"migrate","controller-uid":"1","job-name":"migrate"}},
+				"spec":{"restartPolicy":"Never"}}}}`,
+			expected: map[string]interface{}{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]interface{}{
+					"name":      "migrate",
+					"namespace": "app",
+					"labels":    map[string]interface{}{"app": "migrate"},
+				},
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"labels": map[string]interface{}{"app": "migrate"},
+						},
+						"spec": map[string]interface{}{"restartPolicy": "Never"},
+					},
+				},
+			},
+		},
+		{
+			name: "job with manual selector",
+			live: `{"apiVersion":"batch/v1","kind":"Job",
+				"metadata":{"name":"migrate","namespace":"app","uid":"1"},
+				"spec":{"manualSelector":true,"selector":{"matchLabels":{"app":"migrate"}},
+				"template":{"metadata":{"labels":{"app":"migrate"}},"spec":{"restartPolicy":"Never"}}}}`,
+			expected: map[string]interface{}{
+				"apiVersion": "batch/v1",
+				"kind":       "Job",
+				"metadata": map[string]interface{}{
+					"name":      "migrate",
+					"namespace": "app",
+				},
+				"spec": map[string]interface{}{
+					"manualSelector": true,
+					"selector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{"app": "migrate"},
+					},
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"labels": map[string]interface{}{"app": "migrate"},
+						},
+						"spec": map[string]interface{}{"restartPolicy": "Never"},
+					},
+				},
+			},
+		},
+		{
+			name: "persistent volume claim",
+			live: `{"apiVersion":"v1","kind":"PersistentVolumeClaim",
+				"metadata":{"name":"data","namespace":"app","uid":"1","annotations":{"pv.kubernetes.io/bind-completed":"yes",
+				"volume.beta.kubernetes.io/storage-provisioner":"ebs.csi.aws.com",
+				"volume.kubernetes.io/storage-provisioner":"ebs.csi.aws.com"}},
+				"spec":{"accessModes":["ReadWriteOnce"],"volumeName":"pvc-1","resources":{"requests":{"storage":"1Gi"}}},
+				"status":{"phase":"Bound"}}`,
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "PersistentVolumeClaim",
+				"metadata": map[string]interface{}{
+					"name":      "data",
+					"namespace": "app",
+				},
+				"spec": map[string]interface{}{
+					"accessModes": []interface{}{"ReadWriteOnce"},
+					"resources": map[string]interface{}{
+						"requests": map[string]interface{}{"storage": "1Gi"},
+					},
+				},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			live := &unstructured.Unstructured{}
+			if err := live.UnmarshalJSON([]byte(c.live)); err != nil {
+				t.Fatal(err)
+			}
+			if exported := ExportObject(live); !reflect.DeepEqual(exported.Object, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, exported.Object)
+			}
+			if _, found, _ := unstructured.NestedString(live.Object, "metadata", "uid"); !found {
+				t.Errorf("expected the live object not to be changed")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+// Overlay is a JSON patch of a resource in the manifests, rendered as a Go template with the values of the cluster.
+type Overlay struct {
+	ResourceIdentifier workapiv1.ResourceIdentifier
+	File               string
+	Content            []byte
+}
+
+// ParseOverlay parses the overlay in the format of {resource-id}={file}, and reads the JSON patch in the
+// file in JSON or YAML.
+func ParseOverlay(spec string) (Overlay, error) {
+	resource, file, found := strings.Cut(spec, "=")
+	if !found || len(file) == 0 {
+		return Overlay{}, fmt.Errorf("patch %s format is not correct, should be {resource-id}={file}", spec)
+	}
+	id, err := ParseResourceIdentifier(resource)
+	if err != nil {
+		return Overlay{}, err
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return Overlay{}, err
+	}
+	return Overlay{ResourceIdentifier: id, File: file, Content: content}, nil
+}
+
+// ApplyOverlays renders the overlays with the values of the cluster, and applies them to the manifests of
+// their resources. It is an error if an overlay matches no manifest.
+func ApplyOverlays(manifests []workapiv1.Manifest, overlays []Overlay, values *ClusterValues) ([]workapiv1.Manifest, error) {
+	if len(overlays) == 0 {
+		return manifests, nil
+	}
+
+	patched := make([]workapiv1.Manifest, len(manifests))
+	copy(patched, manifests)
+	for _, overlay := range overlays {
+		rendered, err := RenderTemplate(overlay.File, overlay.Content, values)
+		if err != nil {
+			return nil, err
+		}
+		patchJSON, err := yaml.YAMLToJSON(rendered)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse patch %s: %v", overlay.File, err)
+		}
+		patch, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode patch %s: %v", overlay.File, err)
+		}
+
+		matched := false
+		for i, manifest := range patched {
+			id, err := ResourceIdentifierOf(manifest)
+			if err != nil {
+				return nil, err
+			}
+			if id != overlay.ResourceIdentifier {
+				continue
+			}
+			matched = true
+			if patched[i], err = patchManifest(manifest, patch); err != nil {
+				return nil, fmt.Errorf("failed to apply patch %s to %s: %v", overlay.File, FormatResourceIdentifier(id), err)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("resource %s of patch %s is not found in the manifests",
+				FormatResourceIdentifier(overlay.ResourceIdentifier), overlay.File)
+		}
+	}
+	return patched, nil
+}
+
+func patchManifest(manifest workapiv1.Manifest, patch jsonpatch.Patch) (workapiv1.Manifest, error) {
+	raw := manifest.Raw
+	if manifest.Object != nil {
+		var err error
+		if raw, err = json.Marshal(manifest.Object); err != nil {
+			return workapiv1.Manifest{}, err
+		}
+	}
+	patchedRaw, err := patch.Apply(raw)
+	if err != nil {
+		return workapiv1.Manifest{}, err
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(patchedRaw); err != nil {
+		return workapiv1.Manifest{}, err
+	}
+	return workapiv1.Manifest{RawExtension: runtime.RawExtension{Object: obj}}, nil
+}
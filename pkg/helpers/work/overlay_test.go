@@ -0,0 +1,58 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+func TestApplyOverlays(t *testing.T) {
+	manifests := []workapiv1.Manifest{
+		{RawExtension: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default"},"spec":{"replicas":1}}`),
+		}},
+		{RawExtension: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"}}`),
+		}},
+	}
+	deployment := workapiv1.ResourceIdentifier{Group: "apps", Resource: "deployments", Namespace: "default", Name: "web"}
+	values := &ClusterValues{ClusterName: "cluster1", Labels: map[string]string{"replicas": "3"}}
+
+	overlays := []Overlay{{
+		ResourceIdentifier: deployment,
+		File:               "patch.yaml",
+		Content: []byte(`- op: replace
+  path: /spec/replicas
+  value: {{ index .Labels "replicas" }}
+- op: add
+  path: /metadata/labels
+  value:
+    cluster: {{ .ClusterName }}`),
+	}}
+	patched, err := ApplyOverlays(manifests, overlays, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := patched[0].Object.(*unstructured.Unstructured)
+	if replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas"); replicas != 3 {
+		t.Errorf("expected 3 replicas, got %d", replicas)
+	}
+	if obj.GetLabels()["cluster"] != "cluster1" {
+		t.Errorf("expected the cluster label, got %v", obj.GetLabels())
+	}
+	if patched[1].Object != nil {
+		t.Errorf("expected the configmap not to be patched")
+	}
+	if manifests[0].Object != nil {
+		t.Errorf("expected the manifests not to be changed")
+	}
+
+	overlays[0].ResourceIdentifier.Name = "api"
+	if _, err := ApplyOverlays(manifests, overlays, values); err == nil {
+		t.Errorf("expected error for a patch of a resource not in the manifests")
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workclientset "open-cluster-management.io/api/client/work/clientset/versioned"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+	workapiv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+)
+
+// ReplicaSetWorkSelector returns the label selector of the ManifestWorks created by the ManifestWorkReplicaSet,
+// which are labeled with {namespace}.{name} of the ManifestWorkReplicaSet.
+func ReplicaSetWorkSelector(namespace, name string) string {
+	return fmt.Sprintf("%s=%s.%s", workapiv1alpha1.ManifestWorkReplicaSetControllerNameLabelKey, namespace, name)
+}
+
+// ReplicaSetWorks returns the ManifestWorks created by the ManifestWorkReplicaSet in all the clusters,
+// sorted by the cluster.
+func ReplicaSetWorks(ctx context.Context, workClient workclientset.Interface, namespace, name string) ([]workapiv1.ManifestWork, error) {
+	works, err := workClient.WorkV1().ManifestWorks(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: ReplicaSetWorkSelector(namespace, name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(works.Items, func(i, j int) bool { return works.Items[i].Namespace < works.Items[j].Namespace })
+	return works.Items, nil
+}
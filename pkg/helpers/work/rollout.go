@@ -0,0 +1,171 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+	workapiv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+)
+
+// The rollout types of the --rollout flag.
+const (
+	RolloutAll                 = "all"
+	RolloutProgressive         = "progressive"
+	RolloutProgressivePerGroup = "progressive-per-group"
+)
+
+var (
+	// the patterns follow the validation of the RolloutConfig, with the alternation anchored
+	progressDeadlinePattern = regexp.MustCompile(`^([0-9]+[hms]|None)$`)
+	countOrPercentPattern   = regexp.MustCompile(`^((100|[0-9]{1,2})%|[0-9]+)$`)
+)
+
+// RolloutOptions are the options of the rollout strategy of a ManifestWorkReplicaSet, empty options are
+// left to the defaults of the API.
+type RolloutOptions struct {
+	Type             string
+	MaxConcurrency   string
+	MinSuccessTime   string
+	ProgressDeadline string
+	MaxFailures      string
+}
+
+// RolloutStrategy returns the rollout strategy of the options. The max concurrency is only allowed with
+// progressive, and the min success time only with progressive and progressive-per-group.
+func (o RolloutOptions) RolloutStrategy() (clusterv1alpha1.RolloutStrategy, error) {
+	config := clusterv1alpha1.RolloutConfig{}
+	if len(o.MinSuccessTime) > 0 {
+		if o.Type == RolloutAll {
+			return clusterv1alpha1.RolloutStrategy{}, fmt.Errorf("the min success time is not allowed with the rollout %s", o.Type)
+		}
+		d, err := time.ParseDuration(o.MinSuccessTime)
+		if err != nil {
+			return clusterv1alpha1.RolloutStrategy{}, fmt.Errorf("invalid min success time %s: %v", o.MinSuccessTime, err)
+		}
+		config.MinSuccessTime = metav1.Duration{Duration: d}
+	}
+	if len(o.ProgressDeadline) > 0 {
+		if !progressDeadlinePattern.MatchString(o.ProgressDeadline) {
+			return clusterv1alpha1.RolloutStrategy{}, fmt.Errorf(
+				"invalid progress deadline %s, should be None or in the format of 2h, 90m or 360s", o.ProgressDeadline)
+		}
+		config.ProgressDeadline = o.ProgressDeadline
+	}
+	if len(o.MaxFailures) > 0 {
+		if !countOrPercentPattern.MatchString(o.MaxFailures) {
+			return clusterv1alpha1.RolloutStrategy{}, fmt.Errorf(
+				"invalid max failures %s, should be a number or a percentage", o.MaxFailures)
+		}
+		config.MaxFailures = intstr.Parse(o.MaxFailures)
+	}
+	if len(o.MaxConcurrency) > 0 && o.Type != RolloutProgressive {
+		return clusterv1alpha1.RolloutStrategy{}, fmt.Errorf("the max concurrency is only allowed with the rollout %s", RolloutProgressive)
+	}
+
+	switch o.Type {
+	case RolloutAll:
+		return clusterv1alpha1.RolloutStrategy{
+			Type: clusterv1alpha1.All,
+			All:  &clusterv1alpha1.RolloutAll{RolloutConfig: config},
+		}, nil
+	case RolloutProgressive:
+		progressive := &clusterv1alpha1.RolloutProgressive{RolloutConfig: config}
+		if len(o.MaxConcurrency) > 0 {
+			if !countOrPercentPattern.MatchString(o.MaxConcurrency) {
+				return clusterv1alpha1.RolloutStrategy{}, fmt.Errorf(
+					"invalid max concurrency %s, should be a number or a percentage", o.MaxConcurrency)
+			}
+			progressive.MaxConcurrency = intstr.Parse(o.MaxConcurrency)
+		}
+		return clusterv1alpha1.RolloutStrategy{
+			Type:        clusterv1alpha1.Progressive,
+			Progressive: progressive,
+		}, nil
+	case RolloutProgressivePerGroup:
+		return clusterv1alpha1.RolloutStrategy{
+			Type:                clusterv1alpha1.ProgressivePerGroup,
+			ProgressivePerGroup: &clusterv1alpha1.RolloutProgressivePerGroup{RolloutConfig: config},
+		}, nil
+	}
+	return clusterv1alpha1.RolloutStrategy{}, fmt.Errorf("invalid rollout %s, should be one of %s, %s and %s",
+		o.Type, RolloutAll, RolloutProgressive, RolloutProgressivePerGroup)
+}
+
+// RolloutProgress is the progress of the rollout of a ManifestWorkReplicaSet.
+type RolloutProgress struct {
+	Total     int
+	Available int
+	Failed    []string
+	// MaxFailures is the number of failed clusters tolerated by the rollout strategies
+	MaxFailures int
+	Complete    bool
+}
+
+// String returns the progress in a line.
+func (p RolloutProgress) String() string {
+	return fmt.Sprintf("%d of %d clusters available, %d failed", p.Available, p.Total, len(p.Failed))
+}
+
+// Breached returns true if the failed clusters are more than the rollout tolerates.
+func (p RolloutProgress) Breached() bool {
+	return len(p.Failed) > p.MaxFailures
+}
+
+// ReplicaSetProgress returns the rollout progress of the ManifestWorkReplicaSet with the works created by it.
+// The rollout is complete if the PlacementRolledOut condition of the current generation is Complete, and the
+// works failed or degraded on the clusters count as failures. The condition is stale if it observed an older generation.
+func ReplicaSetProgress(workSet *workapiv1alpha1.ManifestWorkReplicaSet, works []workapiv1.ManifestWork) RolloutProgress {
+	progress := RolloutProgress{Total: workSet.Status.Summary.Total}
+	for i := range works {
+		switch RolloutState(&works[i]) {
+		case StateAvailable:
+			progress.Available++
+		case StateFailed, StateDegraded:
+			progress.Failed = append(progress.Failed, works[i].Namespace)
+		}
+	}
+	if progress.Total < len(works) {
+		progress.Total = len(works)
+	}
+
+	// the failures tolerated by each placement are summed up, as they roll out independently
+	for _, ref := range workSet.Spec.PlacementRefs {
+		maxFailures := maxFailuresOf(ref.RolloutStrategy)
+		total := progress.Total
+		for _, summary := range workSet.Status.PlacementsSummary {
+			if summary.Name == ref.Name {
+				total = summary.Summary.Total
+			}
+		}
+		tolerated, err := intstr.GetScaledValueFromIntOrPercent(&maxFailures, total, false)
+		if err == nil {
+			progress.MaxFailures += tolerated
+		}
+	}
+
+	cond := meta.FindStatusCondition(workSet.Status.Conditions, workapiv1alpha1.ManifestWorkReplicaSetConditionPlacementRolledOut)
+	progress.Complete = cond != nil && cond.Status == metav1.ConditionTrue &&
+		cond.Reason == workapiv1alpha1.ReasonComplete &&
+		(cond.ObservedGeneration == 0 || cond.ObservedGeneration == workSet.Generation)
+	return progress
+}
+
+func maxFailuresOf(strategy clusterv1alpha1.RolloutStrategy) intstr.IntOrString {
+	switch {
+	case strategy.Type == clusterv1alpha1.Progressive && strategy.Progressive != nil:
+		return strategy.Progressive.MaxFailures
+	case strategy.Type == clusterv1alpha1.ProgressivePerGroup && strategy.ProgressivePerGroup != nil:
+		return strategy.ProgressivePerGroup.MaxFailures
+	case strategy.All != nil:
+		return strategy.All.MaxFailures
+	}
+	return intstr.FromInt32(0)
+}
@@ -0,0 +1,122 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+	workapiv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+)
+
+func TestRolloutStrategy(t *testing.T) {
+	cases := []struct {
+		options   RolloutOptions
+		expected  clusterv1alpha1.RolloutStrategy
+		expectErr bool
+	}{
+		{
+			options:  RolloutOptions{Type: RolloutAll},
+			expected: clusterv1alpha1.RolloutStrategy{Type: clusterv1alpha1.All, All: &clusterv1alpha1.RolloutAll{}},
+		},
+		{
+			options: RolloutOptions{Type: RolloutProgressive, MaxConcurrency: "25%", MinSuccessTime: "5m",
+				ProgressDeadline: "30m", MaxFailures: "1"},
+			expected: clusterv1alpha1.RolloutStrategy{
+				Type: clusterv1alpha1.Progressive,
+				Progressive: &clusterv1alpha1.RolloutProgressive{
+					RolloutConfig: clusterv1alpha1.RolloutConfig{
+						MinSuccessTime:   metav1.Duration{Duration: 5 * time.Minute},
+						ProgressDeadline: "30m",
+						MaxFailures:      intstr.FromInt32(1),
+					},
+					MaxConcurrency: intstr.FromString("25%"),
+				},
+			},
+		},
+		{
+			options: RolloutOptions{Type: RolloutProgressivePerGroup, MaxFailures: "10%"},
+			expected: clusterv1alpha1.RolloutStrategy{
+				Type: clusterv1alpha1.ProgressivePerGroup,
+				ProgressivePerGroup: &clusterv1alpha1.RolloutProgressivePerGroup{
+					RolloutConfig: clusterv1alpha1.RolloutConfig{MaxFailures: intstr.FromString("10%")},
+				},
+			},
+		},
+		{options: RolloutOptions{Type: "canary"}, expectErr: true},
+		{options: RolloutOptions{Type: RolloutAll, MinSuccessTime: "5m"}, expectErr: true},
+		{options: RolloutOptions{Type: RolloutProgressivePerGroup, MaxConcurrency: "2"}, expectErr: true},
+		{options: RolloutOptions{Type: RolloutProgressive, ProgressDeadline: "1d"}, expectErr: true},
+		{options: RolloutOptions{Type: RolloutProgressive, ProgressDeadline: "30mfoo"}, expectErr: true},
+		{options: RolloutOptions{Type: RolloutProgressive, ProgressDeadline: "5|"}, expectErr: true},
+		{options: RolloutOptions{Type: RolloutProgressive, ProgressDeadline: "xNone"}, expectErr: true},
+		{options: RolloutOptions{Type: RolloutProgressive, MaxFailures: "200%"}, expectErr: true},
+	}
+	for _, c := range cases {
+		strategy, err := c.options.RolloutStrategy()
+		if c.expectErr != (err != nil) {
+			t.Errorf("expected error %t for %v, got %v", c.expectErr, c.options, err)
+			continue
+		}
+		if !reflect.DeepEqual(strategy, c.expected) {
+			t.Errorf("expected %v for %v, got %v", c.expected, c.options, strategy)
+		}
+	}
+}
+
+func TestReplicaSetProgress(t *testing.T) {
+	newWork := func(cluster string, conditions ...metav1.Condition) workapiv1.ManifestWork {
+		return workapiv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cluster},
+			Status:     workapiv1.ManifestWorkStatus{Conditions: conditions},
+		}
+	}
+	workSet := &workapiv1alpha1.ManifestWorkReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec: workapiv1alpha1.ManifestWorkReplicaSetSpec{
+			PlacementRefs: []workapiv1alpha1.LocalPlacementReference{{
+				Name: "placement1",
+				RolloutStrategy: clusterv1alpha1.RolloutStrategy{
+					Type: clusterv1alpha1.Progressive,
+					Progressive: &clusterv1alpha1.RolloutProgressive{
+						RolloutConfig: clusterv1alpha1.RolloutConfig{MaxFailures: intstr.FromString("25%")},
+					},
+				},
+			}},
+		},
+		Status: workapiv1alpha1.ManifestWorkReplicaSetStatus{
+			Summary: workapiv1alpha1.ManifestWorkReplicaSetSummary{Total: 4},
+			Conditions: []metav1.Condition{{
+				Type:               workapiv1alpha1.ManifestWorkReplicaSetConditionPlacementRolledOut,
+				Status:             metav1.ConditionTrue,
+				Reason:             workapiv1alpha1.ReasonComplete,
+				ObservedGeneration: 1,
+			}},
+		},
+	}
+	works := []workapiv1.ManifestWork{
+		newWork("cluster1", metav1.Condition{Type: workapiv1.WorkApplied, Status: metav1.ConditionTrue},
+			metav1.Condition{Type: workapiv1.WorkAvailable, Status: metav1.ConditionTrue}),
+		newWork("cluster2", metav1.Condition{Type: workapiv1.WorkApplied, Status: metav1.ConditionFalse}),
+		newWork("cluster3"),
+	}
+
+	progress := ReplicaSetProgress(workSet, works)
+	expected := RolloutProgress{Total: 4, Available: 1, Failed: []string{"cluster2"}, MaxFailures: 1}
+	if !reflect.DeepEqual(progress, expected) {
+		t.Errorf("expected %v, got %v", expected, progress)
+	}
+	if progress.Breached() {
+		t.Errorf("expected 1 failure to be tolerated")
+	}
+
+	workSet.Status.Conditions[0].ObservedGeneration = 2
+	if progress := ReplicaSetProgress(workSet, works); !progress.Complete {
+		t.Errorf("expected the rollout of the current generation to be complete")
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+var updateStrategyTypes = []workapiv1.UpdateStrategyType{
+	workapiv1.UpdateStrategyTypeUpdate,
+	workapiv1.UpdateStrategyTypeCreateOnly,
+	workapiv1.UpdateStrategyTypeServerSideApply,
+	workapiv1.UpdateStrategyTypeReadOnly,
+}
+
+var deletePropagationPolicies = []workapiv1.DeletePropagationPolicyType{
+	workapiv1.DeletePropagationPolicyTypeForeground,
+	workapiv1.DeletePropagationPolicyTypeOrphan,
+	workapiv1.DeletePropagationPolicyTypeSelectivelyOrphan,
+}
+
+// ParseResourceIdentifier parses a resource identifier in the format of {resource}[.{group}]/[{namespace}/]{name},
+// e.g. deployments.apps/default/web, or clusterroles.rbac.authorization.k8s.io/admin for a cluster scoped resource.
+func ParseResourceIdentifier(s string) (workapiv1.ResourceIdentifier, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return workapiv1.ResourceIdentifier{}, fmt.Errorf(
+			"resource %s format is not correct, should be {resource}[.{group}]/[{namespace}/]{name}", s)
+	}
+	for _, part := range parts {
+		if len(part) == 0 {
+			return workapiv1.ResourceIdentifier{}, fmt.Errorf(
+				"resource %s format is not correct, should be {resource}[.{group}]/[{namespace}/]{name}", s)
+		}
+	}
+
+	id := workapiv1.ResourceIdentifier{Name: parts[len(parts)-1]}
+	id.Resource, id.Group, _ = strings.Cut(parts[0], ".")
+	if len(parts) == 3 {
+		id.Namespace = parts[1]
+	}
+	return id, nil
+}
+
+// FormatResourceIdentifier returns the resource identifier in the format of {resource}[.{group}]/[{namespace}/]{name}.
+func FormatResourceIdentifier(id workapiv1.ResourceIdentifier) string {
+	s := id.Resource
+	if len(id.Group) > 0 {
+		s += "." + id.Group
+	}
+	if len(id.Namespace) > 0 {
+		s += "/" + id.Namespace
+	}
+	return s + "/" + id.Name
+}
+
+// ResourceIdentifierOf returns the resource identifier of a manifest, the resource is guessed by the kind.
+func ResourceIdentifierOf(manifest workapiv1.Manifest) (workapiv1.ResourceIdentifier, error) {
+	obj := &unstructured.Unstructured{}
+	switch {
+	case manifest.Object != nil:
+		u, ok := manifest.Object.(*unstructured.Unstructured)
+		if !ok {
+			return workapiv1.ResourceIdentifier{}, fmt.Errorf("unexpected manifest type %T", manifest.Object)
+		}
+		obj = u
+	default:
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			return workapiv1.ResourceIdentifier{}, err
+		}
+	}
+
+	gvr, _ := meta.UnsafeGuessKindToResource(obj.GroupVersionKind())
+	return workapiv1.ResourceIdentifier{
+		Group:     gvr.Group,
+		Resource:  gvr.Resource,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}, nil
+}
+
+// ParseDeleteOption returns the delete option with the propagation policy, and the resources orphaned if
+// the policy is SelectivelyOrphan. The policy is SelectivelyOrphan if it is empty with orphans.
+func ParseDeleteOption(policy string, orphans []string) (*workapiv1.DeleteOption, error) {
+	if len(policy) == 0 && len(orphans) == 0 {
+		return nil, nil
+	}
+	if len(policy) == 0 {
+		policy = string(workapiv1.DeletePropagationPolicyTypeSelectivelyOrphan)
+	}
+
+	option := &workapiv1.DeleteOption{PropagationPolicy: workapiv1.DeletePropagationPolicyType(policy)}
+	valid := false
+	for _, p := range deletePropagationPolicies {
+		valid = valid || option.PropagationPolicy == p
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid delete option %s, should be one of Foreground, Orphan and SelectivelyOrphan", policy)
+	}
+	if len(orphans) == 0 {
+		return option, nil
+	}
+	if option.PropagationPolicy != workapiv1.DeletePropagationPolicyTypeSelectivelyOrphan {
+		return nil, fmt.Errorf("the orphaned resources can only be specified with the delete option SelectivelyOrphan")
+	}
+
+	option.SelectivelyOrphan = &workapiv1.SelectivelyOrphan{}
+	for _, orphan := range orphans {
+		id, err := ParseResourceIdentifier(orphan)
+		if err != nil {
+			return nil, err
+		}
+		option.SelectivelyOrphan.OrphaningRules = append(option.SelectivelyOrphan.OrphaningRules, workapiv1.OrphaningRule(id))
+	}
+	return option, nil
+}
+
+// ParseUpdateStrategy parses the update strategy in the format of [{resource-id}=]{strategy}. The resource
+// identifier is nil if the strategy applies to all the manifests.
+func ParseUpdateStrategy(spec string) (*workapiv1.ResourceIdentifier, *workapiv1.UpdateStrategy, error) {
+	var id *workapiv1.ResourceIdentifier
+	strategyType := spec
+	if resource, t, found := strings.Cut(spec, "="); found {
+		parsed, err := ParseResourceIdentifier(resource)
+		if err != nil {
+			return nil, nil, err
+		}
+		id, strategyType = &parsed, t
+	}
+
+	for _, t := range updateStrategyTypes {
+		if workapiv1.UpdateStrategyType(strategyType) == t {
+			return id, &workapiv1.UpdateStrategy{Type: t}, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("invalid update strategy %s, should be one of Update, CreateOnly, ServerSideApply and ReadOnly", spec)
+}
+
+// ParseFeedback parses the status feedback rule in the format of {resource-id}=WellKnownStatus or
+// {resource-id}={name}:{jsonpath}.
+func ParseFeedback(spec string) (workapiv1.ResourceIdentifier, workapiv1.FeedbackRule, error) {
+	resource, rule, found := strings.Cut(spec, "=")
+	if !found {
+		return workapiv1.ResourceIdentifier{}, workapiv1.FeedbackRule{}, fmt.Errorf(
+			"feedback %s format is not correct, should be {resource-id}=WellKnownStatus or {resource-id}={name}:{jsonpath}", spec)
+	}
+	id, err := ParseResourceIdentifier(resource)
+	if err != nil {
+		return workapiv1.ResourceIdentifier{}, workapiv1.FeedbackRule{}, err
+	}
+	if rule == string(workapiv1.WellKnownStatusType) {
+		return id, workapiv1.FeedbackRule{Type: workapiv1.WellKnownStatusType}, nil
+	}
+
+	name, path, found := strings.Cut(rule, ":")
+	if !found || len(name) == 0 || len(path) == 0 {
+		return workapiv1.ResourceIdentifier{}, workapiv1.FeedbackRule{}, fmt.Errorf(
+			"feedback %s format is not correct, should be {resource-id}=WellKnownStatus or {resource-id}={name}:{jsonpath}", spec)
+	}
+	return id, workapiv1.FeedbackRule{
+		Type:      workapiv1.JSONPathsType,
+		JsonPaths: []workapiv1.JsonPath{{Name: name, Path: path}},
+	}, nil
+}
+
+// ManifestConfig returns the manifest config of the resource in the configs, and appends one if it is not found.
+func ManifestConfig(configs *[]workapiv1.ManifestConfigOption, id workapiv1.ResourceIdentifier) *workapiv1.ManifestConfigOption {
+	for i := range *configs {
+		if (*configs)[i].ResourceIdentifier == id {
+			return &(*configs)[i]
+		}
+	}
+	*configs = append(*configs, workapiv1.ManifestConfigOption{ResourceIdentifier: id})
+	return &(*configs)[len(*configs)-1]
+}
+
+// AddFeedbackRule adds the feedback rule to the manifest config, the json paths are merged into the
+// existing JSONPaths rule.
+func AddFeedbackRule(config *workapiv1.ManifestConfigOption, rule workapiv1.FeedbackRule) {
+	for i, r := range config.FeedbackRules {
+		if r.Type != rule.Type {
+			continue
+		}
+		config.FeedbackRules[i].JsonPaths = append(config.FeedbackRules[i].JsonPaths, rule.JsonPaths...)
+		return
+	}
+	config.FeedbackRules = append(config.FeedbackRules, rule)
+}
+
+// ReadSpecFile reads the delete option and manifest configs from the file, which contains either a
+// ManifestWork or its spec. The manifests in the file are not allowed.
+func ReadSpecFile(path string) (*workapiv1.ManifestWorkSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %v", path, err)
+	}
+
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(jsonData, typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %v", path, err)
+	}
+	var target interface{} = &workapiv1.ManifestWorkSpec{}
+	if typeMeta.Kind == "ManifestWork" {
+		target = &workapiv1.ManifestWork{}
+	}
+
+	// unknown fields are rejected, so a typo in the file is not ignored silently
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return nil, fmt.Errorf("failed to parse spec file %s: %v", path, err)
+	}
+	var spec *workapiv1.ManifestWorkSpec
+	if work, ok := target.(*workapiv1.ManifestWork); ok {
+		spec = &work.Spec
+	} else {
+		spec = target.(*workapiv1.ManifestWorkSpec)
+	}
+	if len(spec.Workload.Manifests) > 0 {
+		return nil, fmt.Errorf("spec file %s has manifests, they must be specified by -f, -k or --helm-chart", path)
+	}
+	return spec, nil
+}
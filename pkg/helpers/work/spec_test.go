@@ -0,0 +1,112 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+func TestParseResourceIdentifier(t *testing.T) {
+	cases := map[string]*workapiv1.ResourceIdentifier{
+		"deployments.apps/default/web": {Group: "apps", Resource: "deployments", Namespace: "default", Name: "web"},
+		"configmaps/default/cm":        {Resource: "configmaps", Namespace: "default", Name: "cm"},
+		"clusterroles.rbac.authorization.k8s.io/admin": {
+			Group: "rbac.authorization.k8s.io", Resource: "clusterroles", Name: "admin"},
+		"deployments":        nil,
+		"deployments//web":   nil,
+		"a/b/c/d":            nil,
+		"deployments.apps/a": {Group: "apps", Resource: "deployments", Name: "a"},
+	}
+	for s, expected := range cases {
+		actual, err := ParseResourceIdentifier(s)
+		if expected == nil {
+			if err == nil {
+				t.Errorf("expected error for %s", s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", s, err)
+			continue
+		}
+		if actual != *expected {
+			t.Errorf("expected %v for %s, got %v", *expected, s, actual)
+		}
+		if FormatResourceIdentifier(actual) != s {
+			t.Errorf("expected %s formatted, got %s", s, FormatResourceIdentifier(actual))
+		}
+	}
+}
+
+func TestParseFeedback(t *testing.T) {
+	cases := []struct {
+		spec      string
+		expected  workapiv1.FeedbackRule
+		expectErr bool
+	}{
+		{
+			spec:     "deployments.apps/default/web=WellKnownStatus",
+			expected: workapiv1.FeedbackRule{Type: workapiv1.WellKnownStatusType},
+		},
+		{
+			spec: `deployments.apps/default/web=ready:.status.conditions[?(@.type=="Available")].status`,
+			expected: workapiv1.FeedbackRule{
+				Type:      workapiv1.JSONPathsType,
+				JsonPaths: []workapiv1.JsonPath{{Name: "ready", Path: `.status.conditions[?(@.type=="Available")].status`}},
+			},
+		},
+		{spec: "deployments.apps/default/web", expectErr: true},
+		{spec: "deployments.apps/default/web=.status.replicas", expectErr: true},
+	}
+	for _, c := range cases {
+		_, rule, err := ParseFeedback(c.spec)
+		if c.expectErr != (err != nil) {
+			t.Errorf("expected error %t for %s, got %v", c.expectErr, c.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(rule, c.expected) {
+			t.Errorf("expected %v for %s, got %v", c.expected, c.spec, rule)
+		}
+	}
+}
+
+func TestParseDeleteOption(t *testing.T) {
+	option, err := ParseDeleteOption("", []string{"namespaces/app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &workapiv1.DeleteOption{
+		PropagationPolicy: workapiv1.DeletePropagationPolicyTypeSelectivelyOrphan,
+		SelectivelyOrphan: &workapiv1.SelectivelyOrphan{
+			OrphaningRules: []workapiv1.OrphaningRule{{Resource: "namespaces", Name: "app"}},
+		},
+	}
+	if !reflect.DeepEqual(option, expected) {
+		t.Errorf("expected %v, got %v", expected, option)
+	}
+
+	if _, err := ParseDeleteOption("Orphan", []string{"namespaces/app"}); err == nil {
+		t.Errorf("expected error for orphans with the Orphan policy")
+	}
+	if _, err := ParseDeleteOption("Background", nil); err == nil {
+		t.Errorf("expected error for an invalid policy")
+	}
+}
+
+func TestResourceIdentifierOf(t *testing.T) {
+	manifest := workapiv1.Manifest{RawExtension: runtime.RawExtension{
+		Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default"}}`),
+	}}
+	id, err := ResourceIdentifierOf(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := workapiv1.ResourceIdentifier{Group: "apps", Resource: "deployments", Namespace: "default", Name: "web"}
+	if id != expected {
+		t.Errorf("expected %v, got %v", expected, id)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+// ShardOfLabel is the label of the works split from a work too large for a single object, the value is the name
+// of the work. The shards are named {name}-0 to {name}-N.
+const ShardOfLabel = "clusteradm.open-cluster-management.io/shard-of"
+
+// PlacementLabel is the label of the works created in the clusters selected by a placement, the value is the
+// namespace and name of the placement in the format of {namespace}.{name}, as a label value can not contain a "/".
+const PlacementLabel = "clusteradm.open-cluster-management.io/placement"
+
+// DefaultMaxWorkSize is the default size in bytes of the manifests of a work, below the 1.5MB limit of the objects
+// in etcd, leaving room for the metadata and the status of the resources reported by the work agent.
+const DefaultMaxWorkSize = 1024 * 1024
+
+// Shard is a work split from a work too large for a single object.
+type Shard struct {
+	Name      string
+	Manifests []workapiv1.Manifest
+}
+
+// ShardSelector returns the label selector of the shards of a work.
+func ShardSelector(name string) string {
+	return fmt.Sprintf("%s=%s", ShardOfLabel, name)
+}
+
+// PlacementLabelValue returns the value of the placement label of the placement in the format of {namespace}/{name}.
+func PlacementLabelValue(placement string) string {
+	return strings.Replace(placement, "/", ".", 1)
+}
+
+// SplitManifests splits the manifests into shards with the encoded manifests of each shard no larger than maxSize.
+// The manifests are not split if they fit in a single work, which keeps the name. Otherwise the
+// CustomResourceDefinitions are put in the first shards, so they are applied before the custom resources.
+func SplitManifests(name string, manifests []workapiv1.Manifest, maxSize int) ([]Shard, error) {
+	sizes := make([]int, len(manifests))
+	total := 0
+	for i, manifest := range manifests {
+		size, err := manifestSize(manifest)
+		if err != nil {
+			return nil, err
+		}
+		if size > maxSize {
+			return nil, fmt.Errorf("the manifest %s is %d bytes, larger than the max work size %d", describeManifest(manifest), size, maxSize)
+		}
+		sizes[i] = size
+		total += size
+	}
+	if total <= maxSize {
+		return []Shard{{Name: name, Manifests: manifests}}, nil
+	}
+
+	order := make([]int, len(manifests))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return isCRD(manifests[order[i]]) && !isCRD(manifests[order[j]])
+	})
+
+	var shards []Shard
+	size := 0
+	for _, i := range order {
+		if len(shards) == 0 || size+sizes[i] > maxSize {
+			shards = append(shards, Shard{Name: fmt.Sprintf("%s-%d", name, len(shards))})
+			size = 0
+		}
+		shards[len(shards)-1].Manifests = append(shards[len(shards)-1].Manifests, manifests[i])
+		size += sizes[i]
+	}
+	return shards, nil
+}
+
+func manifestSize(manifest workapiv1.Manifest) (int, error) {
+	if manifest.Object == nil {
+		return len(manifest.Raw), nil
+	}
+	data, err := json.Marshal(manifest.Object)
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func manifestObject(manifest workapiv1.Manifest) *unstructured.Unstructured {
+	if obj, ok := manifest.Object.(*unstructured.Unstructured); ok {
+		return obj
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+		return nil
+	}
+	return obj
+}
+
+func isCRD(manifest workapiv1.Manifest) bool {
+	obj := manifestObject(manifest)
+	if obj == nil {
+		return false
+	}
+	return obj.GroupVersionKind().GroupKind() == schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+}
+
+func describeManifest(manifest workapiv1.Manifest) string {
+	obj := manifestObject(manifest)
+	if obj == nil {
+		return "<unknown>"
+	}
+	if len(obj.GetNamespace()) > 0 {
+		return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+}
+
+// MergeShards merges the shards of a work in a cluster into a work with the name of the label, so they are shown as
+// a single work. The manifests and the resource status are concatenated in the order of the shards, and a condition
+// is the worst of the shards, e.g. a work is not available if any of its shards is not. The other works are kept.
+func MergeShards(works []workapiv1.ManifestWork) []workapiv1.ManifestWork {
+	var merged []workapiv1.ManifestWork
+	index := map[string]int{}
+	shards := map[string][]workapiv1.ManifestWork{}
+	for _, work := range works {
+		name, ok := work.Labels[ShardOfLabel]
+		if !ok {
+			merged = append(merged, work)
+			continue
+		}
+		key := work.Namespace + "/" + name
+		if _, ok := index[key]; !ok {
+			index[key] = len(merged)
+			merged = append(merged, workapiv1.ManifestWork{})
+		}
+		shards[key] = append(shards[key], work)
+	}
+
+	for key, i := range index {
+		merged[i] = mergeWork(shards[key])
+	}
+	return merged
+}
+
+func mergeWork(shards []workapiv1.ManifestWork) workapiv1.ManifestWork {
+	sort.Slice(shards, func(i, j int) bool {
+		return len(shards[i].Name) < len(shards[j].Name) ||
+			(len(shards[i].Name) == len(shards[j].Name) && shards[i].Name < shards[j].Name)
+	})
+
+	work := workapiv1.ManifestWork{
+		TypeMeta: shards[0].TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      shards[0].Labels[ShardOfLabel],
+			Namespace: shards[0].Namespace,
+			Labels:    shards[0].Labels,
+		},
+		Spec: *shards[0].Spec.DeepCopy(),
+	}
+	work.Spec.Workload.Manifests = nil
+	for _, shard := range shards {
+		work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, shard.Spec.Workload.Manifests...)
+		work.Status.ResourceStatus.Manifests = append(work.Status.ResourceStatus.Manifests, shard.Status.ResourceStatus.Manifests...)
+	}
+
+	var conditionTypes []string
+	for _, shard := range shards {
+		for _, cond := range shard.Status.Conditions {
+			if meta.FindStatusCondition(work.Status.Conditions, cond.Type) == nil {
+				conditionTypes = append(conditionTypes, cond.Type)
+				meta.SetStatusCondition(&work.Status.Conditions, cond)
+			}
+		}
+	}
+	for _, conditionType := range conditionTypes {
+		for _, shard := range shards {
+			cond := meta.FindStatusCondition(shard.Status.Conditions, conditionType)
+			if cond == nil {
+				cond = &metav1.Condition{
+					Type:    conditionType,
+					Status:  metav1.ConditionUnknown,
+					Reason:  "ShardNotReported",
+					Message: fmt.Sprintf("the condition is not reported by the work %s", shard.Name),
+				}
+			}
+			current := meta.FindStatusCondition(work.Status.Conditions, conditionType)
+			if conditionRank(cond.Status) < conditionRank(current.Status) {
+				*current = *cond
+			}
+		}
+	}
+	return work
+}
+
+func conditionRank(status metav1.ConditionStatus) int {
+	switch status {
+	case metav1.ConditionFalse:
+		return 0
+	case metav1.ConditionUnknown:
+		return 1
+	}
+	return 2
+}
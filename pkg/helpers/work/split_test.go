@@ -0,0 +1,112 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+func rawManifest(kind, name string, size int) workapiv1.Manifest {
+	apiVersion := "v1"
+	if kind == "CustomResourceDefinition" {
+		apiVersion = "apiextensions.k8s.io/v1"
+	}
+	raw := fmt.Sprintf(`{"apiVersion":%q,"kind":%q,"metadata":{"name":%q},"data":{"a":%q}}`,
+		apiVersion, kind, name, strings.Repeat("a", size))
+	return workapiv1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+}
+
+func TestSplitManifests(t *testing.T) {
+	cm1 := rawManifest("ConfigMap", "cm1", 100)
+	cm2 := rawManifest("ConfigMap", "cm2", 100)
+	crd := rawManifest("CustomResourceDefinition", "crd", 100)
+	size := len(cm1.Raw)
+
+	cases := []struct {
+		name      string
+		manifests []workapiv1.Manifest
+		maxSize   int
+		expected  map[string][]string
+		expectErr bool
+	}{
+		{
+			name:      "not split",
+			manifests: []workapiv1.Manifest{cm1, cm2},
+			maxSize:   3 * size,
+			expected:  map[string][]string{"work": {"cm1", "cm2"}},
+		},
+		{
+			name:      "crds first",
+			manifests: []workapiv1.Manifest{cm1, cm2, crd},
+			maxSize:   len(crd.Raw) + size,
+			expected:  map[string][]string{"work-0": {"crd", "cm1"}, "work-1": {"cm2"}},
+		},
+		{
+			name:      "manifest too large",
+			manifests: []workapiv1.Manifest{cm1},
+			maxSize:   size / 2,
+			expectErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			shards, err := SplitManifests("work", c.manifests, c.maxSize)
+			if c.expectErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := map[string][]string{}
+			for _, shard := range shards {
+				for _, manifest := range shard.Manifests {
+					actual[shard.Name] = append(actual[shard.Name], manifestObject(manifest).GetName())
+				}
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMergeShards(t *testing.T) {
+	shard := func(name string, available metav1.ConditionStatus) workapiv1.ManifestWork {
+		return workapiv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "cluster1", Labels: map[string]string{ShardOfLabel: "work"}},
+			Spec: workapiv1.ManifestWorkSpec{Workload: workapiv1.ManifestsTemplate{
+				Manifests: []workapiv1.Manifest{rawManifest("ConfigMap", name, 1)},
+			}},
+			Status: workapiv1.ManifestWorkStatus{Conditions: []metav1.Condition{
+				{Type: workapiv1.WorkApplied, Status: metav1.ConditionTrue},
+				{Type: workapiv1.WorkAvailable, Status: available},
+			}},
+		}
+	}
+	other := workapiv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "cluster1"}}
+
+	merged := MergeShards([]workapiv1.ManifestWork{other, shard("work-1", metav1.ConditionFalse), shard("work-0", metav1.ConditionTrue)})
+	if len(merged) != 2 || merged[0].Name != "other" || merged[1].Name != "work" {
+		t.Fatalf("expected the works other and work, got %v", merged)
+	}
+	if n := len(merged[1].Spec.Workload.Manifests); n != 2 {
+		t.Errorf("expected 2 manifests, got %d", n)
+	}
+	if !meta.IsStatusConditionTrue(merged[1].Status.Conditions, workapiv1.WorkApplied) {
+		t.Errorf("expected the work to be applied")
+	}
+	if !meta.IsStatusConditionFalse(merged[1].Status.Conditions, workapiv1.WorkAvailable) {
+		t.Errorf("expected the work not to be available")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+// Feedback is a status feedback value of a resource in a work.
+type Feedback struct {
+	ResourceMeta workapiv1.ManifestResourceMeta
+	Resource     string
+	Name         string
+	Value        string
+}
+
+// ManifestFailure is a failed condition of a resource in a work.
+type ManifestFailure struct {
+	ResourceMeta workapiv1.ManifestResourceMeta
+	Resource     string
+	Condition    string
+	Reason       string
+	Message      string
+}
+
+// ResourceOf returns the resource of the manifest status in the format of {resource}[.{group}]/[{namespace}/]{name}.
+func ResourceOf(resourceMeta workapiv1.ManifestResourceMeta) string {
+	return FormatResourceIdentifier(workapiv1.ResourceIdentifier{
+		Group:     resourceMeta.Group,
+		Resource:  resourceMeta.Resource,
+		Namespace: resourceMeta.Namespace,
+		Name:      resourceMeta.Name,
+	})
+}
+
+// Feedbacks returns the status feedback values of the resources in the work.
+func Feedbacks(work *workapiv1.ManifestWork) []Feedback {
+	var feedbacks []Feedback
+	for _, manifest := range work.Status.ResourceStatus.Manifests {
+		for _, value := range manifest.StatusFeedbacks.Values {
+			feedbacks = append(feedbacks, Feedback{
+				ResourceMeta: manifest.ResourceMeta,
+				Resource:     ResourceOf(manifest.ResourceMeta),
+				Name:         value.Name,
+				Value:        FormatFieldValue(value.Value),
+			})
+		}
+	}
+	return feedbacks
+}
+
+// FormatFieldValue returns the value of a status feedback by its type.
+func FormatFieldValue(value workapiv1.FieldValue) string {
+	switch {
+	case value.Integer != nil:
+		return fmt.Sprintf("%d", *value.Integer)
+	case value.String != nil:
+		return *value.String
+	case value.Boolean != nil:
+		return fmt.Sprintf("%t", *value.Boolean)
+	case value.JsonRaw != nil:
+		return *value.JsonRaw
+	}
+	return ""
+}
+
+// ManifestFailures returns the resources in the work which are not applied, not available or degraded.
+func ManifestFailures(work *workapiv1.ManifestWork) []ManifestFailure {
+	var failures []ManifestFailure
+	for _, manifest := range work.Status.ResourceStatus.Manifests {
+		for _, conditionType := range []string{workapiv1.ManifestApplied, workapiv1.ManifestAvailable, workapiv1.ManifestDegraded} {
+			cond := meta.FindStatusCondition(manifest.Conditions, conditionType)
+			if cond == nil {
+				continue
+			}
+			// a resource is degraded if the condition is True, and failed to be applied or available if False
+			failed := cond.Status == metav1.ConditionFalse
+			if conditionType == workapiv1.ManifestDegraded {
+				failed = cond.Status == metav1.ConditionTrue
+			}
+			if !failed {
+				continue
+			}
+			failures = append(failures, ManifestFailure{
+				ResourceMeta: manifest.ResourceMeta,
+				Resource:     ResourceOf(manifest.ResourceMeta),
+				Condition:    fmt.Sprintf("%s=%s", cond.Type, cond.Status),
+				Reason:       cond.Reason,
+				Message:      cond.Message,
+			})
+		}
+	}
+	return failures
+}
+
+// The rollout states of a ManifestWork on a cluster.
+const (
+	StatePending     = "Pending"
+	StateFailed      = "Failed"
+	StateDegraded    = "Degraded"
+	StateProgressing = "Progressing"
+	StateApplied     = "Applied"
+	StateAvailable   = "Available"
+)
+
+// RolloutState returns the rollout state of the work by its conditions. A work is Failed if it is not
+// applied, and Degraded or Progressing before it is Available.
+func RolloutState(work *workapiv1.ManifestWork) string {
+	switch {
+	case meta.IsStatusConditionFalse(work.Status.Conditions, workapiv1.WorkApplied):
+		return StateFailed
+	case meta.IsStatusConditionTrue(work.Status.Conditions, workapiv1.WorkDegraded):
+		return StateDegraded
+	case meta.IsStatusConditionTrue(work.Status.Conditions, workapiv1.WorkProgressing):
+		return StateProgressing
+	case meta.IsStatusConditionTrue(work.Status.Conditions, workapiv1.WorkAvailable):
+		return StateAvailable
+	case meta.IsStatusConditionTrue(work.Status.Conditions, workapiv1.WorkApplied):
+		return StateApplied
+	}
+	return StatePending
+}
@@ -0,0 +1,94 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+func TestStatus(t *testing.T) {
+	deployment := workapiv1.ManifestResourceMeta{Group: "apps", Resource: "deployments", Namespace: "default", Name: "web"}
+	configMap := workapiv1.ManifestResourceMeta{Resource: "configmaps", Namespace: "default", Name: "cm"}
+	work := &workapiv1.ManifestWork{
+		Status: workapiv1.ManifestWorkStatus{
+			ResourceStatus: workapiv1.ManifestResourceStatus{
+				Manifests: []workapiv1.ManifestCondition{
+					{
+						ResourceMeta: deployment,
+						StatusFeedbacks: workapiv1.StatusFeedbackResult{
+							Values: []workapiv1.FeedbackValue{
+								{Name: "ReadyReplicas", Value: workapiv1.FieldValue{Type: workapiv1.Integer, Integer: ptr.To[int64](2)}},
+							},
+						},
+						Conditions: []metav1.Condition{
+							{Type: workapiv1.ManifestApplied, Status: metav1.ConditionTrue},
+							{Type: workapiv1.ManifestAvailable, Status: metav1.ConditionTrue},
+							{Type: workapiv1.ManifestDegraded, Status: metav1.ConditionTrue, Reason: "Unhealthy", Message: "1 replica is unavailable"},
+						},
+					},
+					{
+						ResourceMeta: configMap,
+						Conditions: []metav1.Condition{
+							{Type: workapiv1.ManifestApplied, Status: metav1.ConditionFalse, Reason: "AppliedManifestFailed", Message: "forbidden"},
+							{Type: workapiv1.ManifestDegraded, Status: metav1.ConditionFalse},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expectedFeedbacks := []Feedback{
+		{ResourceMeta: deployment, Resource: "deployments.apps/default/web", Name: "ReadyReplicas", Value: "2"},
+	}
+	if feedbacks := Feedbacks(work); !reflect.DeepEqual(feedbacks, expectedFeedbacks) {
+		t.Errorf("expected feedbacks %v, got %v", expectedFeedbacks, feedbacks)
+	}
+
+	expectedFailures := []ManifestFailure{
+		{ResourceMeta: deployment, Resource: "deployments.apps/default/web", Condition: "Degraded=True",
+			Reason: "Unhealthy", Message: "1 replica is unavailable"},
+		{ResourceMeta: configMap, Resource: "configmaps/default/cm", Condition: "Applied=False",
+			Reason: "AppliedManifestFailed", Message: "forbidden"},
+	}
+	if failures := ManifestFailures(work); !reflect.DeepEqual(failures, expectedFailures) {
+		t.Errorf("expected failures %v, got %v", expectedFailures, failures)
+	}
+}
+
+func TestRolloutState(t *testing.T) {
+	cases := map[string][]metav1.Condition{
+		StatePending: nil,
+		StateFailed: {
+			{Type: workapiv1.WorkApplied, Status: metav1.ConditionFalse},
+			{Type: workapiv1.WorkDegraded, Status: metav1.ConditionTrue},
+		},
+		StateDegraded: {
+			{Type: workapiv1.WorkApplied, Status: metav1.ConditionTrue},
+			{Type: workapiv1.WorkAvailable, Status: metav1.ConditionTrue},
+			{Type: workapiv1.WorkDegraded, Status: metav1.ConditionTrue},
+		},
+		StateProgressing: {
+			{Type: workapiv1.WorkApplied, Status: metav1.ConditionTrue},
+			{Type: workapiv1.WorkProgressing, Status: metav1.ConditionTrue},
+		},
+		StateAvailable: {
+			{Type: workapiv1.WorkApplied, Status: metav1.ConditionTrue},
+			{Type: workapiv1.WorkAvailable, Status: metav1.ConditionTrue},
+		},
+		StateApplied: {
+			{Type: workapiv1.WorkApplied, Status: metav1.ConditionTrue},
+		},
+	}
+	for expected, conditions := range cases {
+		work := &workapiv1.ManifestWork{Status: workapiv1.ManifestWorkStatus{Conditions: conditions}}
+		if state := RolloutState(work); state != expected {
+			t.Errorf("expected state %s, got %s", expected, state)
+		}
+	}
+}
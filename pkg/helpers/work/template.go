@@ -0,0 +1,57 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+// ClusterValues are the values of a managed cluster to render the per-cluster templates of a work, e.g.
+// {{ .ClusterName }}, {{ index .Labels "env" }}, {{ index .Annotations "mirror" }} or
+// {{ index .Claims "region.open-cluster-management.io" }}.
+type ClusterValues struct {
+	ClusterName string
+	Labels      map[string]string
+	Annotations map[string]string
+	Claims      map[string]string
+}
+
+// NewClusterValues returns the values of the cluster, with the claims reported in its status.
+func NewClusterValues(cluster *clusterv1.ManagedCluster) *ClusterValues {
+	values := &ClusterValues{
+		ClusterName: cluster.Name,
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+		Claims:      map[string]string{},
+	}
+	for key, value := range cluster.Labels {
+		values.Labels[key] = value
+	}
+	for key, value := range cluster.Annotations {
+		values.Annotations[key] = value
+	}
+	for _, claim := range cluster.Status.ClusterClaims {
+		values.Claims[claim.Name] = claim.Value
+	}
+	return values
+}
+
+// RenderTemplate renders the Go template with the values of the cluster. A missing key is an error, and
+// so is any reference to the cluster if values is nil.
+func RenderTemplate(name string, content []byte, values *ClusterValues) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %v", name, err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, values); err != nil {
+		if values == nil {
+			return nil, fmt.Errorf("template %s can not refer to the cluster when it is not rendered per cluster: %v", name, err)
+		}
+		return nil, fmt.Errorf("failed to render template %s for cluster %s: %v", name, values.ClusterName, err)
+	}
+	return buf.Bytes(), nil
+}
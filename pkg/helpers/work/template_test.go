@@ -0,0 +1,65 @@
+// Copyright Contributors to the Open Cluster Management project
+package work
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster1",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"mirror": "registry.local"},
+		},
+		Status: clusterv1.ManagedClusterStatus{
+			ClusterClaims: []clusterv1.ManagedClusterClaim{{Name: "region.open-cluster-management.io", Value: "us-east-1"}},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		content   string
+		values    *ClusterValues
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "render the cluster values",
+			content:  `{{ .ClusterName }}-{{ index .Labels "env" }}-{{ index .Claims "region.open-cluster-management.io" }}-{{ index .Annotations "mirror" }}`,
+			values:   NewClusterValues(cluster),
+			expected: "cluster1-prod-us-east-1-registry.local",
+		},
+		{
+			name:     "no cluster values referred",
+			content:  "replicas: 2",
+			expected: "replicas: 2",
+		},
+		{
+			name:      "refer to the cluster without values",
+			content:   "name: {{ .ClusterName }}",
+			expectErr: true,
+		},
+		{
+			name:      "missing key",
+			content:   "{{ .Zone }}",
+			values:    NewClusterValues(cluster),
+			expectErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := RenderTemplate("values.yaml", []byte(c.content), c.values)
+			if c.expectErr != (err != nil) {
+				t.Fatalf("expected error %t, got %v", c.expectErr, err)
+			}
+			if string(actual) != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, string(actual))
+			}
+		})
+	}
+}